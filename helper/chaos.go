@@ -0,0 +1,230 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A Fault is a Senzing error Chaos injects, with its relative frequency.
+type Fault struct {
+	ErrorName string
+	Weight    int
+}
+
+// RetryableFaults are Senzing errors a client is expected to retry.
+var RetryableFaults = []Fault{
+	{ErrorName: SzErrorDatabaseConnectionLost, Weight: 1},
+}
+
+// UnrecoverableFaults are Senzing errors a client is not expected to retry.
+var UnrecoverableFaults = []Fault{
+	{ErrorName: SzErrorDatabase, Weight: 1},
+	{ErrorName: SzErrorNotInitialized, Weight: 1},
+}
+
+// ChaosSettings describe the faults and slow calls injected into calls.
+type ChaosSettings struct {
+	FailureRate float64
+	Faults      []Fault
+	SlowLatency Latency
+	SlowRate    float64
+}
+
+// ChaosCount describes what Chaos injected into the calls to one method.
+type ChaosCount struct {
+	Calls     int
+	Faults    map[string]int
+	SlowCalls int
+}
+
+// Chaos randomly fails and slows calls to mocks.  It is safe for concurrent use.
+type Chaos struct {
+	counts   map[string]*ChaosCount
+	mutex    sync.Mutex
+	random   *rand.Rand
+	settings map[string]ChaosSettings
+}
+
+/*
+The NewChaos function creates Chaos applying the same settings to every call.
+
+Input
+  - seed: The seed of the random number generator.  The same seed and calls give the same faults.
+  - settings: The settings of calls without more specific settings.
+    FailureRate and SlowRate are fractions of calls between 0 and 1.
+    Without Faults, RetryableFaults are injected.
+    It panics if the ErrorName of a Fault is not in SzErrorCatalog.
+
+Output
+  - Chaos to be assigned to the Chaos field of mocks or Szabstractfactory.
+*/
+func NewChaos(seed int64, settings ChaosSettings) *Chaos {
+	checkFaults(settings.Faults)
+	return &Chaos{
+		counts:   map[string]*ChaosCount{},
+		random:   rand.New(rand.NewSource(seed)), //nolint:gosec // Reproducible, not secure, randomness.
+		settings: map[string]ChaosSettings{"": settings},
+	}
+}
+
+/*
+The Report method returns what was injected, keyed by "component.method" (e.g. "szengine.AddRecord").
+
+Output
+  - A copy of the counts of calls, faults by error name, and slow calls.
+*/
+func (chaos *Chaos) Report() map[string]ChaosCount {
+	chaos.mutex.Lock()
+	defer chaos.mutex.Unlock()
+	result := make(map[string]ChaosCount, len(chaos.counts))
+	for key, count := range chaos.counts {
+		faults := make(map[string]int, len(count.Faults))
+		for errorName, faultCount := range count.Faults {
+			faults[errorName] = faultCount
+		}
+		result[key] = ChaosCount{
+			Calls:     count.Calls,
+			Faults:    faults,
+			SlowCalls: count.SlowCalls,
+		}
+	}
+	return result
+}
+
+/*
+The Reset method clears the report.
+*/
+func (chaos *Chaos) Reset() {
+	chaos.mutex.Lock()
+	defer chaos.mutex.Unlock()
+	chaos.counts = map[string]*ChaosCount{}
+}
+
+/*
+The Set method sets the settings of a component, a method, or a method of a component.
+The most specific settings apply: component and method, then method, then component, then the settings of NewChaos().
+
+Input
+  - component: The name of the package of the mock (e.g. "szengine").  Empty for all components.
+  - method: The name of the method (e.g. "AddRecord").  Empty for all methods.
+  - settings: The settings.  It panics if the ErrorName of a Fault is not in SzErrorCatalog.
+*/
+func (chaos *Chaos) Set(component string, method string, settings ChaosSettings) {
+	checkFaults(settings.Faults)
+	chaos.mutex.Lock()
+	defer chaos.mutex.Unlock()
+	chaos.settings[getMethodKey(component, method)] = settings
+}
+
+// Decide the fault and delay of a call.
+func (chaos *Chaos) decide(component string, method string) (string, time.Duration) {
+	chaos.mutex.Lock()
+	defer chaos.mutex.Unlock()
+	settings := chaos.getSettings(component, method)
+	key := getMethodKey(component, method)
+	count, ok := chaos.counts[key]
+	if !ok {
+		count = &ChaosCount{Faults: map[string]int{}}
+		chaos.counts[key] = count
+	}
+	count.Calls++
+	var delay time.Duration
+	if settings.SlowLatency != nil && chaos.random.Float64() < settings.SlowRate {
+		count.SlowCalls++
+		delay = settings.SlowLatency.Duration(chaos.random)
+	}
+	if chaos.random.Float64() >= settings.FailureRate {
+		return "", delay
+	}
+	errorName := chaos.pickFault(settings.Faults)
+	count.Faults[errorName]++
+	return errorName, delay
+}
+
+// Get the most specific settings.  The caller holds the mutex.
+func (chaos *Chaos) getSettings(component string, method string) ChaosSettings {
+	for _, key := range []string{getMethodKey(component, method), getMethodKey("", method), getMethodKey(component, "")} {
+		if settings, ok := chaos.settings[key]; ok {
+			return settings
+		}
+	}
+	return chaos.settings[""]
+}
+
+// Pick a fault by weight.  The caller holds the mutex.
+func (chaos *Chaos) pickFault(faults []Fault) string {
+	if len(faults) == 0 {
+		faults = RetryableFaults
+	}
+	totalWeight := 0
+	for _, fault := range faults {
+		totalWeight += fault.Weight
+	}
+	if totalWeight <= 0 {
+		return faults[0].ErrorName
+	}
+	choice := chaos.random.Intn(totalWeight)
+	for _, fault := range faults {
+		choice -= fault.Weight
+		if choice < 0 {
+			return fault.ErrorName
+		}
+	}
+	return faults[len(faults)-1].ErrorName
+}
+
+// ----------------------------------------------------------------------------
+// Functions used by mock objects
+// ----------------------------------------------------------------------------
+
+/*
+The InjectFault function randomly slows and fails a call.
+
+Input
+  - ctx: The context of the call.  A slow call ends early if ctx is cancelled or expires.
+  - chaos: The mock's Chaos.  May be nil.
+  - component: The name of the package of the mock (e.g. "szengine").
+  - method: The name of the method called.
+  - err: The error the mock would otherwise return.
+
+Output
+  - The injected error, ctx.Err() if the context ended during a slow call, or err.
+*/
+func InjectFault(ctx context.Context, chaos *Chaos, component string, method string, err error) error {
+	if chaos == nil {
+		return err
+	}
+	errorName, delay := chaos.decide(component, method)
+	if delay > 0 {
+		if delayErr := sleep(ctx, delay); delayErr != nil {
+			return delayErr
+		}
+	}
+	if len(errorName) == 0 {
+		return err
+	}
+	return NewSzErrorFromCatalog(errorName, "chaos", method)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Panic if a Fault would not inject a Senzing error.
+func checkFaults(faults []Fault) {
+	for _, fault := range faults {
+		if _, ok := SzErrorCatalog[fault.ErrorName]; !ok {
+			panic(fmt.Sprintf("Chaos: unknown Senzing error name %q", fault.ErrorName))
+		}
+	}
+}
+
+func getMethodKey(component string, method string) string {
+	if len(component) == 0 || len(method) == 0 {
+		return component + method
+	}
+	return component + "." + method
+}
@@ -0,0 +1,111 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestChaos_InjectFault(test *testing.T) {
+	ctx := context.TODO()
+	chaos := NewChaos(1, ChaosSettings{FailureRate: 0.25})
+	failures := 0
+	for range make([]struct{}, 1000) {
+		err := InjectFault(ctx, chaos, "szengine", "AddRecord", nil)
+		if err != nil {
+			failures++
+			require.True(test, errors.Is(err, szerror.ErrSzRetryable), err)
+		}
+	}
+	assert.InDelta(test, 250, failures, 50)
+	report := chaos.Report()
+	assert.Equal(test, 1000, report["szengine.AddRecord"].Calls)
+	assert.Equal(test, map[string]int{SzErrorDatabaseConnectionLost: failures}, report["szengine.AddRecord"].Faults)
+}
+
+func TestChaos_InjectFault_faults(test *testing.T) {
+	ctx := context.TODO()
+	chaos := NewChaos(1, ChaosSettings{
+		FailureRate: 1,
+		Faults: []Fault{
+			{ErrorName: SzErrorDatabaseConnectionLost, Weight: 3},
+			{ErrorName: SzErrorDatabase, Weight: 1},
+		},
+	})
+	for range make([]struct{}, 1000) {
+		require.Error(test, InjectFault(ctx, chaos, "szengine", "GetRecord", nil))
+	}
+	faults := chaos.Report()["szengine.GetRecord"].Faults
+	assert.Equal(test, 1000, faults[SzErrorDatabaseConnectionLost]+faults[SzErrorDatabase])
+	assert.InDelta(test, 750, faults[SzErrorDatabaseConnectionLost], 60)
+}
+
+func TestChaos_InjectFault_unknownFault(test *testing.T) {
+	unknownFaults := []Fault{{ErrorName: "NoSuchError", Weight: 1}}
+	assert.Panics(test, func() { NewChaos(1, ChaosSettings{FailureRate: 1, Faults: unknownFaults}) })
+	chaos := NewChaos(1, ChaosSettings{})
+	assert.Panics(test, func() { chaos.Set("szengine", "GetRecord", ChaosSettings{FailureRate: 1, Faults: unknownFaults}) })
+	require.NoError(test, InjectFault(context.TODO(), chaos, "szengine", "GetRecord", nil))
+}
+
+func TestChaos_InjectFault_nil(test *testing.T) {
+	expectedErr := errors.New("expected")
+	require.ErrorIs(test, InjectFault(context.TODO(), nil, "szengine", "AddRecord", expectedErr), expectedErr)
+}
+
+func TestChaos_InjectFault_seed(test *testing.T) {
+	sample := func(seed int64) []bool {
+		chaos := NewChaos(seed, ChaosSettings{FailureRate: 0.5})
+		result := []bool{}
+		for range make([]struct{}, 20) {
+			result = append(result, InjectFault(context.TODO(), chaos, "szengine", "AddRecord", nil) != nil)
+		}
+		return result
+	}
+	assert.Equal(test, sample(7), sample(7))
+	assert.NotEqual(test, sample(7), sample(8))
+}
+
+func TestChaos_InjectFault_slow(test *testing.T) {
+	ctx := context.TODO()
+	chaos := NewChaos(1, ChaosSettings{})
+	chaos.Set("szengine", "", ChaosSettings{SlowRate: 1, SlowLatency: FixedLatency(time.Millisecond)})
+	chaos.Set("szengine", "GetRecord", ChaosSettings{SlowRate: 1, SlowLatency: FixedLatency(time.Hour)})
+	start := time.Now()
+	require.NoError(test, InjectFault(ctx, chaos, "szengine", "AddRecord", nil))
+	assert.GreaterOrEqual(test, time.Since(start), time.Millisecond)
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(test, InjectFault(ctxWithTimeout, chaos, "szengine", "GetRecord", nil), context.DeadlineExceeded)
+	require.NoError(test, InjectFault(ctx, chaos, "szconfig", "GetRecord", nil))
+	report := chaos.Report()
+	assert.Equal(test, 1, report["szengine.AddRecord"].SlowCalls)
+	assert.Equal(test, 1, report["szengine.GetRecord"].SlowCalls)
+	assert.Equal(test, 0, report["szconfig.GetRecord"].SlowCalls)
+	chaos.Reset()
+	assert.Empty(test, chaos.Report())
+}
+
+func TestChaos_Set(test *testing.T) {
+	ctx := context.TODO()
+	chaos := NewChaos(1, ChaosSettings{})
+	chaos.Set("", "Destroy", ChaosSettings{FailureRate: 1})
+	chaos.Set("szengine", "", ChaosSettings{FailureRate: 1, Faults: UnrecoverableFaults})
+	chaos.Set("szengine", "PrimeEngine", ChaosSettings{})
+	require.NoError(test, InjectFault(ctx, chaos, "szconfig", "CreateConfig", nil))
+	require.Error(test, InjectFault(ctx, chaos, "szconfig", "Destroy", nil))
+	require.Error(test, InjectFault(ctx, chaos, "szengine", "Destroy", nil))
+	err := InjectFault(ctx, chaos, "szengine", "AddRecord", nil)
+	require.Error(test, err)
+	assert.False(test, errors.Is(err, szerror.ErrSzRetryable))
+	require.NoError(test, InjectFault(ctx, chaos, "szengine", "PrimeEngine", nil))
+}
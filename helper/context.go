@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"context"
+)
+
+/*
+The ContextError function returns the error of a cancelled or expired context, if checking is enabled.
+
+Input
+  - ctx: The context of the call.
+  - isEnabled: Whether the mock honors the context (e.g. Szengine.HonorContext).
+  - err: The error the mock would otherwise return.
+
+Output
+  - ctx.Err() if isEnabled and the context is done; otherwise err.
+*/
+func ContextError(ctx context.Context, isEnabled bool, err error) error {
+	if !isEnabled || ctx == nil {
+		return err
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
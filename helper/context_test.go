@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestContextError(test *testing.T) {
+	expectedErr := fmt.Errorf("expected")
+	ctx, cancel := context.WithCancel(context.TODO())
+	require.ErrorIs(test, ContextError(ctx, true, expectedErr), expectedErr)
+	cancel()
+	require.ErrorIs(test, ContextError(ctx, true, expectedErr), context.Canceled)
+	require.ErrorIs(test, ContextError(ctx, false, expectedErr), expectedErr)
+	require.NoError(test, ContextError(ctx, false, nil))
+}
+
+func TestContextError_deadline(test *testing.T) {
+	ctx, cancel := context.WithDeadline(context.TODO(), time.Now().Add(-time.Second))
+	defer cancel()
+	require.ErrorIs(test, ContextError(ctx, true, nil), context.DeadlineExceeded)
+}
@@ -0,0 +1,439 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const anyTimes = -1
+
+// ----------------------------------------------------------------------------
+// Matchers
+// ----------------------------------------------------------------------------
+
+// A Matcher decides whether an argument of a call satisfies an Expectation.
+type Matcher interface {
+	Matches(argument interface{}) bool
+	String() string
+}
+
+type anyMatcher struct{}
+
+func (matcher anyMatcher) Matches(argument interface{}) bool {
+	_ = argument
+	return true
+}
+
+func (matcher anyMatcher) String() string {
+	return "any"
+}
+
+type eqMatcher struct {
+	value interface{}
+}
+
+func (matcher eqMatcher) Matches(argument interface{}) bool {
+	return isEqual(matcher.value, argument)
+}
+
+func (matcher eqMatcher) String() string {
+	return fmt.Sprintf("%#v", matcher.value)
+}
+
+type jsonEqMatcher struct {
+	document interface{}
+	text     string
+}
+
+func (matcher jsonEqMatcher) Matches(argument interface{}) bool {
+	text, ok := argument.(string)
+	if !ok {
+		return false
+	}
+	var document interface{}
+	if err := json.Unmarshal([]byte(text), &document); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(matcher.document, document)
+}
+
+func (matcher jsonEqMatcher) String() string {
+	return fmt.Sprintf("JSON equivalent to %s", matcher.text)
+}
+
+type regexMatcher struct {
+	regex *regexp.Regexp
+}
+
+func (matcher regexMatcher) Matches(argument interface{}) bool {
+	return matcher.regex.MatchString(fmt.Sprint(argument))
+}
+
+func (matcher regexMatcher) String() string {
+	return fmt.Sprintf("matching /%s/", matcher.regex.String())
+}
+
+/*
+The Any function returns a Matcher that matches any argument.
+*/
+func Any() Matcher {
+	return anyMatcher{}
+}
+
+/*
+The Eq function returns a Matcher that matches arguments equal to value.
+Integers of different types are compared by value, so Eq(1001) matches int64(1001).
+
+Input
+  - value: The expected value of the argument.
+*/
+func Eq(value interface{}) Matcher {
+	return eqMatcher{value: value}
+}
+
+/*
+The JSONEq function returns a Matcher that matches JSON documents equivalent to document,
+regardless of whitespace and key order.
+It panics if document is not valid JSON.
+
+Input
+  - document: The expected JSON document.
+*/
+func JSONEq(document string) Matcher {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+		panic(fmt.Sprintf("JSONEq: invalid JSON %q: %v", document, err))
+	}
+	return jsonEqMatcher{document: parsed, text: document}
+}
+
+/*
+The Regex function returns a Matcher that matches arguments whose string form matches pattern.
+It panics if pattern is not a valid regular expression.
+
+Input
+  - pattern: A regular expression.
+*/
+func Regex(pattern string) Matcher {
+	return regexMatcher{regex: regexp.MustCompile(pattern)}
+}
+
+// ----------------------------------------------------------------------------
+// Expectation
+// ----------------------------------------------------------------------------
+
+// An Expectation describes a call a test expects a mock to receive.
+type Expectation struct {
+	calls     int
+	err       error
+	hasReturn bool
+	matchers  []Matcher
+	method    string
+	result    interface{}
+	times     int
+}
+
+/*
+The AnyTimes method allows the expected call to happen any number of times, including zero.
+*/
+func (expectation *Expectation) AnyTimes() *Expectation {
+	expectation.times = anyTimes
+	return expectation
+}
+
+/*
+The Return method sets the values returned by the mock when the call is matched.
+Without Return, a matched call returns the mock's usual values.
+
+Input
+  - result: The value returned by the method.  It must have the method's result type (e.g. int64(1), not 1).
+    Ignored for methods returning only an error.
+  - err: The error returned by the method.
+*/
+func (expectation *Expectation) Return(result interface{}, err error) *Expectation {
+	expectation.hasReturn = true
+	expectation.result = result
+	expectation.err = err
+	return expectation
+}
+
+/*
+The ReturnError method sets the error returned by the mock when the call is matched.
+
+Input
+  - err: The error returned by the method.
+*/
+func (expectation *Expectation) ReturnError(err error) *Expectation {
+	return expectation.Return(nil, err)
+}
+
+/*
+The Times method sets the exact number of times the call is expected.  The default is once.
+
+Input
+  - times: The expected number of calls.
+*/
+func (expectation *Expectation) Times(times int) *Expectation {
+	expectation.times = times
+	return expectation
+}
+
+func (expectation *Expectation) String() string {
+	matchers := make([]string, len(expectation.matchers))
+	for i, matcher := range expectation.matchers {
+		matchers[i] = matcher.String()
+	}
+	return fmt.Sprintf("%s(%s)", expectation.method, strings.Join(matchers, ", "))
+}
+
+func (expectation *Expectation) isExhausted() bool {
+	return expectation.times != anyTimes && expectation.calls >= expectation.times
+}
+
+func (expectation *Expectation) matches(method string, arguments []interface{}) bool {
+	if expectation.method != method || len(expectation.matchers) != len(arguments) {
+		return false
+	}
+	for i, matcher := range expectation.matchers {
+		if !matcher.Matches(arguments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ----------------------------------------------------------------------------
+// Expectations
+// ----------------------------------------------------------------------------
+
+// Expectations holds the Expectations for a mock object.  It is safe for concurrent use.
+type Expectations struct {
+	expectations []*Expectation
+	isStrict     bool
+	mutex        sync.Mutex
+	testingTB    testing.TB
+	unexpected   []string
+	verified     bool
+}
+
+/*
+The NewExpectations function creates an empty set of Expectations.
+Verify() is called automatically when the test completes.
+Expectations are only useful inside tests, where they report unexpected calls through testingTB
+and register Verify() with testingTB.Cleanup(), which is why this package imports "testing".
+Since Go 1.13 that import registers no flags, so it does not affect programs using the mocks outside tests.
+
+Input
+  - testingTB: The test reporting failures.
+
+Output
+  - An empty set of Expectations to be assigned to a mock's Expectations field.
+*/
+func NewExpectations(testingTB testing.TB) *Expectations {
+	result := &Expectations{
+		testingTB: testingTB,
+	}
+	testingTB.Cleanup(result.Verify)
+	return result
+}
+
+/*
+The Call method matches a call against the Expectations.
+Expectations are tried in the order they were declared; exhausted Expectations are skipped.
+It is used by the mock objects.
+
+Input
+  - method: The name of the method called.
+  - arguments: The arguments of the call, excluding the context.
+
+Output
+  - The matching Expectation or nil.
+*/
+func (expectations *Expectations) Call(method string, arguments ...interface{}) *Expectation {
+	if expectations == nil {
+		return nil
+	}
+	expectations.mutex.Lock()
+	defer expectations.mutex.Unlock()
+	for _, expectation := range expectations.expectations {
+		if !expectation.isExhausted() && expectation.matches(method, arguments) {
+			expectation.calls++
+			return expectation
+		}
+	}
+	description := fmt.Sprintf("%s(%s)", method, formatArguments(arguments))
+	if expectations.isStrict {
+		expectations.testingTB.Helper()
+		expectations.testingTB.Errorf("unexpected call to %s", description)
+		return nil
+	}
+	expectations.unexpected = append(expectations.unexpected, description)
+	return nil
+}
+
+/*
+The Expect method declares an expected call.
+Arguments that are not Matchers are matched with Eq().
+
+Input
+  - method: The name of the method expected to be called (e.g. "DeleteRecord").
+  - arguments: Matchers for every argument of the method, excluding the context.
+
+Output
+  - The Expectation, which can be refined with Times(), AnyTimes() and Return().
+*/
+func (expectations *Expectations) Expect(method string, arguments ...interface{}) *Expectation {
+	expectation := &Expectation{
+		method: method,
+		times:  1,
+	}
+	for _, argument := range arguments {
+		matcher, ok := argument.(Matcher)
+		if !ok {
+			matcher = Eq(argument)
+		}
+		expectation.matchers = append(expectation.matchers, matcher)
+	}
+	expectations.mutex.Lock()
+	defer expectations.mutex.Unlock()
+	expectations.expectations = append(expectations.expectations, expectation)
+	return expectation
+}
+
+/*
+The SetStrict method controls whether a call not matching any Expectation fails the test immediately.
+When not strict, unexpected calls are reported by Verify().
+
+Input
+  - isStrict: true to fail on unexpected calls.
+*/
+func (expectations *Expectations) SetStrict(isStrict bool) {
+	expectations.mutex.Lock()
+	defer expectations.mutex.Unlock()
+	expectations.isStrict = isStrict
+}
+
+/*
+The Verify method fails the test if an Expectation was not met or an unexpected call occurred.
+Only the first call to Verify reports failures.
+*/
+func (expectations *Expectations) Verify() {
+	expectations.mutex.Lock()
+	defer expectations.mutex.Unlock()
+	if expectations.verified {
+		return
+	}
+	expectations.verified = true
+	expectations.testingTB.Helper()
+	for _, expectation := range expectations.expectations {
+		if expectation.times != anyTimes && expectation.calls != expectation.times {
+			expectations.testingTB.Errorf("expected %d call(s) to %s, got %d", expectation.times, expectation, expectation.calls)
+		}
+	}
+	for _, description := range expectations.unexpected {
+		expectations.testingTB.Errorf("unexpected call to %s", description)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Functions used by mock objects
+// ----------------------------------------------------------------------------
+
+/*
+The ExpectedError function returns the error of the Expectation matching the call, if any.
+
+Input
+  - expectations: The mock's Expectations.  May be nil.
+  - method: The name of the method called.
+  - err: The error the mock would otherwise return.
+  - arguments: The arguments of the call, excluding the context.
+
+Output
+  - The error to be returned by the mock.
+*/
+func ExpectedError(expectations *Expectations, method string, err error, arguments ...interface{}) error {
+	expectation := expectations.Call(method, arguments...)
+	if expectation == nil || !expectation.hasReturn {
+		return err
+	}
+	return expectation.err
+}
+
+/*
+The ExpectedResult function returns the result and error of the Expectation matching the call, if any.
+
+Input
+  - expectations: The mock's Expectations.  May be nil.
+  - method: The name of the method called.
+  - result: The result the mock would otherwise return.
+  - err: The error the mock would otherwise return.
+  - arguments: The arguments of the call, excluding the context.
+
+Output
+  - The result and error to be returned by the mock.
+*/
+func ExpectedResult[T any](expectations *Expectations, method string, result T, err error, arguments ...interface{}) (T, error) {
+	expectation := expectations.Call(method, arguments...)
+	if expectation == nil || !expectation.hasReturn {
+		return result, err
+	}
+	typedResult, convertErr := convertResult[T](expectation.result)
+	if convertErr != nil {
+		expectations.testingTB.Errorf("%s: %v", expectation, convertErr)
+		return typedResult, fmt.Errorf("%s: %w", method, convertErr)
+	}
+	return typedResult, expectation.err
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Assert a configured result has the result type of a method.  nil is the zero value.
+// Values are not converted, so Return(1, nil) does not fit an int64 result; use Return(int64(1), nil).
+func convertResult[T any](result interface{}) (T, error) {
+	var typedResult T
+	if result == nil {
+		return typedResult, nil
+	}
+	typedResult, ok := result.(T)
+	if !ok {
+		return typedResult, fmt.Errorf("result %#v has type %T, not the method's result type %s", result, result, reflect.TypeOf(&typedResult).Elem())
+	}
+	return typedResult, nil
+}
+
+func formatArguments(arguments []interface{}) string {
+	result := make([]string, len(arguments))
+	for i, argument := range arguments {
+		result[i] = fmt.Sprintf("%#v", argument)
+	}
+	return strings.Join(result, ", ")
+}
+
+func isEqual(expected interface{}, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	expectedValue := reflect.ValueOf(expected)
+	actualValue := reflect.ValueOf(actual)
+	if isInteger(expectedValue) && isInteger(actualValue) {
+		return fmt.Sprint(expected) == fmt.Sprint(actual)
+	}
+	return false
+}
+
+func isInteger(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package helper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A testingTB that collects failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	cleanups []func()
+	failures []string
+}
+
+func (testingTB *recordingTB) Cleanup(cleanup func()) {
+	testingTB.cleanups = append(testingTB.cleanups, cleanup)
+}
+
+func (testingTB *recordingTB) Errorf(format string, args ...interface{}) {
+	testingTB.failures = append(testingTB.failures, fmt.Sprintf(format, args...))
+}
+
+func (testingTB *recordingTB) Helper() {}
+
+func (testingTB *recordingTB) runCleanups() {
+	for _, cleanup := range testingTB.cleanups {
+		cleanup()
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestMatchers(test *testing.T) {
+	assert.True(test, Any().Matches(nil))
+	assert.True(test, Eq("CUSTOMERS").Matches("CUSTOMERS"))
+	assert.False(test, Eq("CUSTOMERS").Matches("REFERENCE"))
+	assert.True(test, Eq(1001).Matches(int64(1001)))
+	assert.False(test, Eq(1001).Matches("1001"))
+	assert.True(test, Regex(`^10\d\d$`).Matches("1001"))
+	assert.False(test, Regex(`^10\d\d$`).Matches("2001"))
+	assert.True(test, JSONEq(`{"A": 1, "B": [1, 2]}`).Matches(`{"B":[1,2],"A":1}`))
+	assert.False(test, JSONEq(`{"A": 1}`).Matches(`{"A": 2}`))
+	assert.False(test, JSONEq(`{"A": 1}`).Matches(`}{`))
+	assert.Panics(test, func() { JSONEq(`}{`) })
+}
+
+func TestExpectations_ExpectedResult(test *testing.T) {
+	testingTB := &recordingTB{}
+	expectations := NewExpectations(testingTB)
+	expectedErr := fmt.Errorf("expected")
+	expectations.Expect("DeleteRecord", "CUSTOMERS", Any(), int64(0)).Times(2).Return("{}", nil)
+	expectations.Expect("DeleteRecord", "CUSTOMERS", "1003", int64(0)).Return("", expectedErr)
+	expectations.Expect("CountRedoRecords").Return(int64(5), nil)
+	for _, recordID := range []string{"1001", "1002"} {
+		result, err := ExpectedResult(expectations, "DeleteRecord", "static", nil, "CUSTOMERS", recordID, int64(0))
+		require.NoError(test, err)
+		assert.Equal(test, "{}", result)
+	}
+	_, err := ExpectedResult(expectations, "DeleteRecord", "static", nil, "CUSTOMERS", "1003", int64(0))
+	require.ErrorIs(test, err, expectedErr)
+	count, err := ExpectedResult(expectations, "CountRedoRecords", int64(0), nil)
+	require.NoError(test, err)
+	assert.Equal(test, int64(5), count)
+	testingTB.runCleanups()
+	assert.Empty(test, testingTB.failures)
+}
+
+func TestExpectations_ExpectedResult_typeMismatch(test *testing.T) {
+	testingTB := &recordingTB{}
+	expectations := NewExpectations(testingTB)
+	expectations.Expect("GetRecord").Return(65, nil)
+	expectations.Expect("CountRedoRecords").Return(int32(5), nil)
+	result, err := ExpectedResult(expectations, "GetRecord", "static", nil)
+	require.Error(test, err)
+	assert.Empty(test, result)
+	_, err = ExpectedResult(expectations, "CountRedoRecords", int64(0), nil)
+	require.Error(test, err)
+	assert.Len(test, testingTB.failures, 2)
+}
+
+func TestExpectations_ExpectedError(test *testing.T) {
+	testingTB := &recordingTB{}
+	expectations := NewExpectations(testingTB)
+	expectedErr := fmt.Errorf("expected")
+	expectations.Expect("Destroy").ReturnError(expectedErr)
+	expectations.Expect("PrimeEngine").AnyTimes()
+	require.ErrorIs(test, ExpectedError(expectations, "Destroy", nil), expectedErr)
+	require.NoError(test, ExpectedError(expectations, "PrimeEngine", nil))
+	testingTB.runCleanups()
+	assert.Empty(test, testingTB.failures)
+}
+
+func TestExpectations_nil(test *testing.T) {
+	result, err := ExpectedResult(nil, "GetRecord", "static", nil, "CUSTOMERS", "1001", int64(0))
+	require.NoError(test, err)
+	assert.Equal(test, "static", result)
+}
+
+func TestExpectations_Verify(test *testing.T) {
+	testingTB := &recordingTB{}
+	expectations := NewExpectations(testingTB)
+	expectations.Expect("AddRecord", "CUSTOMERS", "1001", Any(), Any()).Times(2)
+	_, _ = ExpectedResult(expectations, "AddRecord", "", nil, "CUSTOMERS", "1001", "{}", int64(0))
+	_, _ = ExpectedResult(expectations, "GetRecord", "", nil, "CUSTOMERS", "1001", int64(0))
+	assert.Empty(test, testingTB.failures)
+	testingTB.runCleanups()
+	expectations.Verify()
+	require.Len(test, testingTB.failures, 2)
+	assert.Contains(test, testingTB.failures[0], `expected 2 call(s) to AddRecord("CUSTOMERS", "1001", any, any), got 1`)
+	assert.Contains(test, testingTB.failures[1], `unexpected call to GetRecord("CUSTOMERS", "1001", 0)`)
+}
+
+func TestExpectations_SetStrict(test *testing.T) {
+	testingTB := &recordingTB{}
+	expectations := NewExpectations(testingTB)
+	expectations.SetStrict(true)
+	expectations.Expect("GetRecord", "CUSTOMERS", "1001", Any())
+	_, _ = ExpectedResult(expectations, "GetRecord", "", nil, "CUSTOMERS", "1001", int64(0))
+	_, _ = ExpectedResult(expectations, "GetRecord", "", nil, "CUSTOMERS", "1001", int64(0))
+	require.Len(test, testingTB.failures, 1)
+	assert.Contains(test, testingTB.failures[0], "unexpected call to GetRecord")
+	testingTB.runCleanups()
+	assert.Len(test, testingTB.failures, 1)
+}
@@ -0,0 +1,72 @@
+package helpertest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/senzing-garage/go-observing/observer"
+)
+
+// Observable is the part of every mock that manages logging and observers.
+type Observable interface {
+	GetObserverOrigin(ctx context.Context) string
+	RegisterObserver(ctx context.Context, observer observer.Observer) error
+	SetLogLevel(ctx context.Context, logLevelName string) error
+	SetObserverOrigin(ctx context.Context, origin string)
+	UnregisterObserver(ctx context.Context, observer observer.Observer) error
+}
+
+/*
+The RunConcurrently function calls a mock from concurrent workers to expose data races under "go test -race".
+In each iteration, a worker registers its own observer, sets the log level (TRACE for even workers),
+sets the observer origin, makes a call, and unregisters its observer.
+RunConcurrently returns once all workers are done.
+
+Input
+  - ctx: A context to control lifecycle.
+  - testingTB: The test reporting failures.
+  - observable: The mock.
+  - workers: The number of concurrent workers.
+  - iterations: The number of iterations of each worker.
+  - call: Calls the mock for a worker and iteration.  A returned error fails the test.
+*/
+func RunConcurrently(ctx context.Context, testingTB testing.TB, observable Observable, workers int, iterations int, call func(worker int, iteration int) error) {
+	testingTB.Helper()
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func(worker int) {
+			defer waitGroup.Done()
+			workerObserver := &observer.NullObserver{
+				ID:       fmt.Sprintf("Observer %d", worker),
+				IsSilent: true,
+			}
+			logLevelName := logging.LevelInfoName
+			if worker%2 == 0 {
+				logLevelName = logging.LevelTraceName
+			}
+			for iteration := 0; iteration < iterations; iteration++ {
+				if err := observable.RegisterObserver(ctx, workerObserver); err != nil {
+					testingTB.Errorf("worker %d: RegisterObserver: %v", worker, err)
+				}
+				if err := observable.SetLogLevel(ctx, logLevelName); err != nil {
+					testingTB.Errorf("worker %d: SetLogLevel: %v", worker, err)
+				}
+				observable.SetObserverOrigin(ctx, workerObserver.ID)
+				if len(observable.GetObserverOrigin(ctx)) == 0 {
+					testingTB.Errorf("worker %d: GetObserverOrigin is empty", worker)
+				}
+				if err := call(worker, iteration); err != nil {
+					testingTB.Errorf("worker %d, iteration %d: %v", worker, iteration, err)
+				}
+				if err := observable.UnregisterObserver(ctx, workerObserver); err != nil {
+					testingTB.Errorf("worker %d: UnregisterObserver: %v", worker, err)
+				}
+			}
+		}(worker)
+	}
+	waitGroup.Wait()
+}
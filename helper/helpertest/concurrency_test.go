@@ -0,0 +1,75 @@
+package helpertest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/senzing-garage/go-observing/observer"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingObservable struct {
+	mutex          sync.Mutex
+	observerOrigin string
+	registered     map[string]bool
+	unregistered   int
+}
+
+func (observable *countingObservable) GetObserverOrigin(ctx context.Context) string {
+	_ = ctx
+	observable.mutex.Lock()
+	defer observable.mutex.Unlock()
+	return observable.observerOrigin
+}
+
+func (observable *countingObservable) RegisterObserver(ctx context.Context, observer observer.Observer) error {
+	observable.mutex.Lock()
+	defer observable.mutex.Unlock()
+	observable.registered[observer.GetObserverID(ctx)] = true
+	return nil
+}
+
+func (observable *countingObservable) SetLogLevel(ctx context.Context, logLevelName string) error {
+	_ = ctx
+	_ = logLevelName
+	return nil
+}
+
+func (observable *countingObservable) SetObserverOrigin(ctx context.Context, origin string) {
+	_ = ctx
+	observable.mutex.Lock()
+	defer observable.mutex.Unlock()
+	observable.observerOrigin = origin
+}
+
+func (observable *countingObservable) UnregisterObserver(ctx context.Context, observer observer.Observer) error {
+	_ = ctx
+	_ = observer
+	observable.mutex.Lock()
+	defer observable.mutex.Unlock()
+	observable.unregistered++
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestHelpertest_RunConcurrently(test *testing.T) {
+	ctx := context.TODO()
+	observable := &countingObservable{
+		registered: map[string]bool{},
+	}
+	var calls atomic.Int64
+	RunConcurrently(ctx, test, observable, 4, 10, func(worker int, iteration int) error {
+		_ = worker
+		_ = iteration
+		calls.Add(1)
+		return nil
+	})
+	assert.Equal(test, int64(4*10), calls.Load())
+	assert.Len(test, observable.registered, 4)
+	assert.Equal(test, 4*10, observable.unregistered)
+}
@@ -0,0 +1,5 @@
+/*
+The helpertest package holds test support shared by the tests of the mock packages.
+It imports "testing", so production code should not import it.
+*/
+package helpertest
@@ -0,0 +1,246 @@
+package helper
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// The z-score of the 99th percentile of the standard normal distribution.
+const zScore99 = 2.326348
+
+// A Latency produces the time a simulated call takes.
+type Latency interface {
+	Duration(random *rand.Rand) time.Duration
+}
+
+type fixedLatency struct {
+	duration time.Duration
+}
+
+func (latency fixedLatency) Duration(random *rand.Rand) time.Duration {
+	_ = random
+	return latency.duration
+}
+
+type logNormalLatency struct {
+	mu    float64
+	sigma float64
+}
+
+func (latency logNormalLatency) Duration(random *rand.Rand) time.Duration {
+	return time.Duration(math.Exp(latency.mu + latency.sigma*random.NormFloat64()))
+}
+
+type normalLatency struct {
+	mean   time.Duration
+	stdDev time.Duration
+}
+
+func (latency normalLatency) Duration(random *rand.Rand) time.Duration {
+	result := latency.mean + time.Duration(random.NormFloat64()*float64(latency.stdDev))
+	if result < 0 {
+		return 0
+	}
+	return result
+}
+
+type uniformLatency struct {
+	maximum time.Duration
+	minimum time.Duration
+}
+
+func (latency uniformLatency) Duration(random *rand.Rand) time.Duration {
+	if latency.maximum <= latency.minimum {
+		return latency.minimum
+	}
+	return latency.minimum + time.Duration(random.Int63n(int64(latency.maximum-latency.minimum)+1))
+}
+
+/*
+The FixedLatency function returns a Latency that is always the same.
+
+Input
+  - duration: The time every call takes.
+*/
+func FixedLatency(duration time.Duration) Latency {
+	return fixedLatency{duration: duration}
+}
+
+/*
+The LogNormalLatency function returns a Latency with a log-normal distribution,
+the usual shape of service response times, described by two percentiles.
+
+Input
+  - median: The 50th percentile.
+  - p99: The 99th percentile.  Must not be less than median.
+*/
+func LogNormalLatency(median time.Duration, p99 time.Duration) Latency {
+	mu := math.Log(float64(median))
+	sigma := 0.0
+	if p99 > median {
+		sigma = (math.Log(float64(p99)) - mu) / zScore99
+	}
+	return logNormalLatency{mu: mu, sigma: sigma}
+}
+
+/*
+The NormalLatency function returns a Latency with a normal distribution.  Negative values are returned as 0.
+
+Input
+  - mean: The mean.
+  - stdDev: The standard deviation.
+*/
+func NormalLatency(mean time.Duration, stdDev time.Duration) Latency {
+	return normalLatency{mean: mean, stdDev: stdDev}
+}
+
+/*
+The UniformLatency function returns a Latency evenly distributed between minimum and maximum, inclusive.
+
+Input
+  - minimum: The shortest time a call takes.
+  - maximum: The longest time a call takes.
+*/
+func UniformLatency(minimum time.Duration, maximum time.Duration) Latency {
+	return uniformLatency{maximum: maximum, minimum: minimum}
+}
+
+// ----------------------------------------------------------------------------
+// Latencies
+// ----------------------------------------------------------------------------
+
+// Latencies holds the Latency of each method of one or more mocks, keyed by component and method.  It is safe for concurrent use.
+type Latencies struct {
+	defaultLatency Latency
+	latencies      map[string]Latency
+	mutex          sync.Mutex
+	random         *rand.Rand
+}
+
+/*
+The NewLatencies function creates Latencies without any delay.
+
+Input
+  - seed: The seed of the random number generator.  The same seed gives the same sequence of delays.
+
+Output
+  - Latencies to be assigned to the Latencies field of mocks or Szabstractfactory.
+*/
+func NewLatencies(seed int64) *Latencies {
+	return &Latencies{
+		latencies: map[string]Latency{},
+		random:    rand.New(rand.NewSource(seed)), //nolint:gosec // Reproducible, not secure, randomness.
+	}
+}
+
+/*
+The Duration method returns the next simulated duration of a call to a method.
+
+Input
+  - component: The name of the package of the mock (e.g. "szengine").
+  - method: The name of the method (e.g. "AddRecord").
+
+Output
+  - The time the call takes.
+*/
+func (latencies *Latencies) Duration(component string, method string) time.Duration {
+	latencies.mutex.Lock()
+	defer latencies.mutex.Unlock()
+	latency := latencies.defaultLatency
+	for _, key := range []string{getMethodKey(component, method), getMethodKey("", method), getMethodKey(component, "")} {
+		if specificLatency, ok := latencies.latencies[key]; ok {
+			latency = specificLatency
+			break
+		}
+	}
+	if latency == nil {
+		return 0
+	}
+	return latency.Duration(latencies.random)
+}
+
+/*
+The Set method sets the Latency of a component, a method, or a method of a component.
+The most specific Latency applies: component and method, then method, then component, then the default.
+
+Input
+  - component: The name of the package of the mock (e.g. "szengine").  Empty for all components.
+  - method: The name of the method (e.g. "AddRecord").  Empty for all methods.
+  - latency: The Latency.  nil removes it.
+*/
+func (latencies *Latencies) Set(component string, method string, latency Latency) {
+	latencies.mutex.Lock()
+	defer latencies.mutex.Unlock()
+	key := getMethodKey(component, method)
+	if latency == nil {
+		delete(latencies.latencies, key)
+		return
+	}
+	latencies.latencies[key] = latency
+}
+
+/*
+The SetDefault method sets the Latency of methods without their own.
+
+Input
+  - latency: The default Latency.  nil means no delay.
+*/
+func (latencies *Latencies) SetDefault(latency Latency) {
+	latencies.mutex.Lock()
+	defer latencies.mutex.Unlock()
+	latencies.defaultLatency = latency
+}
+
+// ----------------------------------------------------------------------------
+// Functions used by mock objects
+// ----------------------------------------------------------------------------
+
+/*
+The Delay function waits for the simulated duration of a call.
+The wait ends early if ctx is cancelled or expires.
+
+Input
+  - ctx: The context of the call.
+  - latencies: The mock's Latencies.  May be nil.
+  - component: The name of the package of the mock (e.g. "szengine").
+  - method: The name of the method called.
+  - err: The error the mock would otherwise return.
+
+Output
+  - ctx.Err() if the context ended during the wait; otherwise err.
+*/
+func Delay(ctx context.Context, latencies *Latencies, component string, method string, err error) error {
+	if latencies == nil {
+		return err
+	}
+	if delayErr := sleep(ctx, latencies.Duration(component, method)); delayErr != nil {
+		return delayErr
+	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Wait for a duration or until ctx is done.
+func sleep(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+	if ctx == nil {
+		time.Sleep(duration)
+		return nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestLatencies_Duration(test *testing.T) {
+	latencies := NewLatencies(1)
+	assert.Equal(test, time.Duration(0), latencies.Duration("szengine", "AddRecord"))
+	latencies.SetDefault(FixedLatency(time.Millisecond))
+	latencies.Set("", "AddRecord", UniformLatency(2*time.Millisecond, 3*time.Millisecond))
+	assert.Equal(test, time.Millisecond, latencies.Duration("szengine", "GetRecord"))
+	for range make([]struct{}, 100) {
+		duration := latencies.Duration("szengine", "AddRecord")
+		assert.GreaterOrEqual(test, duration, 2*time.Millisecond)
+		assert.LessOrEqual(test, duration, 3*time.Millisecond)
+	}
+	latencies.Set("", "AddRecord", nil)
+	assert.Equal(test, time.Millisecond, latencies.Duration("szengine", "AddRecord"))
+}
+
+func TestLatencies_Duration_component(test *testing.T) {
+	latencies := NewLatencies(1)
+	latencies.SetDefault(FixedLatency(time.Millisecond))
+	latencies.Set("szengine", "", FixedLatency(2*time.Millisecond))
+	latencies.Set("", "GetVersion", FixedLatency(3*time.Millisecond))
+	latencies.Set("szproduct", "GetVersion", FixedLatency(4*time.Millisecond))
+	assert.Equal(test, 2*time.Millisecond, latencies.Duration("szengine", "AddRecord"))
+	assert.Equal(test, 3*time.Millisecond, latencies.Duration("szengine", "GetVersion"))
+	assert.Equal(test, 4*time.Millisecond, latencies.Duration("szproduct", "GetVersion"))
+	assert.Equal(test, time.Millisecond, latencies.Duration("szproduct", "GetLicense"))
+	latencies.Set("szproduct", "GetVersion", nil)
+	assert.Equal(test, 3*time.Millisecond, latencies.Duration("szproduct", "GetVersion"))
+}
+
+func TestLatencies_Duration_seed(test *testing.T) {
+	sample := func(seed int64) []time.Duration {
+		latencies := NewLatencies(seed)
+		latencies.SetDefault(NormalLatency(10*time.Millisecond, 2*time.Millisecond))
+		result := []time.Duration{}
+		for range make([]struct{}, 10) {
+			result = append(result, latencies.Duration("szengine", "AddRecord"))
+		}
+		return result
+	}
+	assert.Equal(test, sample(42), sample(42))
+	assert.NotEqual(test, sample(42), sample(43))
+}
+
+func TestLatencies_LogNormalLatency(test *testing.T) {
+	latencies := NewLatencies(7)
+	latencies.SetDefault(LogNormalLatency(10*time.Millisecond, 100*time.Millisecond))
+	durations := make([]time.Duration, 10000)
+	for i := range durations {
+		durations[i] = latencies.Duration("szengine", "GetEntityByRecordID")
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	assert.InDelta(test, float64(10*time.Millisecond), float64(durations[5000]), float64(time.Millisecond))
+	assert.InDelta(test, float64(100*time.Millisecond), float64(durations[9900]), float64(15*time.Millisecond))
+}
+
+func TestLatencies_NormalLatency_notNegative(test *testing.T) {
+	latencies := NewLatencies(1)
+	latencies.SetDefault(NormalLatency(0, time.Second))
+	for range make([]struct{}, 100) {
+		assert.GreaterOrEqual(test, latencies.Duration("szengine", "AddRecord"), time.Duration(0))
+	}
+}
+
+func TestDelay(test *testing.T) {
+	ctx := context.TODO()
+	expectedErr := fmt.Errorf("expected")
+	latencies := NewLatencies(1)
+	latencies.SetDefault(FixedLatency(10 * time.Millisecond))
+	start := time.Now()
+	require.ErrorIs(test, Delay(ctx, latencies, "szengine", "AddRecord", expectedErr), expectedErr)
+	assert.GreaterOrEqual(test, time.Since(start), 10*time.Millisecond)
+	require.NoError(test, Delay(ctx, nil, "szengine", "AddRecord", nil))
+}
+
+func TestDelay_cancel(test *testing.T) {
+	latencies := NewLatencies(1)
+	latencies.SetDefault(FixedLatency(time.Hour))
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(test, Delay(ctx, latencies, "szengine", "AddRecord", nil), context.DeadlineExceeded)
+}
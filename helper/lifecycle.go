@@ -0,0 +1,95 @@
+package helper
+
+import (
+	"sync"
+)
+
+const (
+	lifecycleUninitialized = iota
+	lifecycleInitialized
+	lifecycleDestroyed
+)
+
+/*
+Lifecycle tracks whether a mock has been initialized or destroyed.
+The zero value is uninitialized.  It is safe for concurrent use.
+
+Mocks track their lifecycle at all times, but only return errors when strict
+(e.g. Szengine.StrictLifecycle), as the Senzing SDK rejects:
+
+  - Calls before Initialize() or after Destroy(): NotInitialized
+  - Initialize() when initialized: BadInput
+  - Destroy() when not initialized, including a second Destroy(): NotInitialized
+*/
+type Lifecycle struct {
+	mutex sync.Mutex
+	state int
+}
+
+/*
+The Check method rejects a call made outside of Initialize() and Destroy().
+
+Input
+  - isStrict: Whether the mock enforces its lifecycle.
+  - err: The error the mock would otherwise return.
+
+Output
+  - A NotInitialized error if isStrict and the mock is not initialized; otherwise err.
+*/
+func (lifecycle *Lifecycle) Check(isStrict bool, err error) error {
+	if !isStrict {
+		return err
+	}
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+	if lifecycle.state != lifecycleInitialized {
+		return NewSzErrorFromCatalog(SzErrorNotInitialized)
+	}
+	return err
+}
+
+/*
+The Destroy method records a call to Destroy().
+
+Input
+  - isStrict: Whether the mock enforces its lifecycle.
+  - err: The error the mock would otherwise return.  If not nil, the lifecycle does not change.
+
+Output
+  - A NotInitialized error if isStrict and the mock is not initialized; otherwise err.
+*/
+func (lifecycle *Lifecycle) Destroy(isStrict bool, err error) error {
+	if err != nil {
+		return err
+	}
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+	if isStrict && lifecycle.state != lifecycleInitialized {
+		return NewSzErrorFromCatalog(SzErrorNotInitialized)
+	}
+	lifecycle.state = lifecycleDestroyed
+	return nil
+}
+
+/*
+The Initialize method records a call to Initialize().  A destroyed mock may be initialized again.
+
+Input
+  - isStrict: Whether the mock enforces its lifecycle.
+  - err: The error the mock would otherwise return.  If not nil, the lifecycle does not change.
+
+Output
+  - A BadInput error if isStrict and the mock is already initialized; otherwise err.
+*/
+func (lifecycle *Lifecycle) Initialize(isStrict bool, err error) error {
+	if err != nil {
+		return err
+	}
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+	if isStrict && lifecycle.state == lifecycleInitialized {
+		return NewSzErrorFromCatalog(SzErrorBadInput, "already initialized")
+	}
+	lifecycle.state = lifecycleInitialized
+	return nil
+}
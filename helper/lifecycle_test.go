@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestLifecycle_strict(test *testing.T) {
+	lifecycle := &Lifecycle{}
+	require.ErrorIs(test, lifecycle.Check(true, nil), szerror.ErrSzNotInitialized)
+	require.ErrorIs(test, lifecycle.Destroy(true, nil), szerror.ErrSzNotInitialized)
+	require.NoError(test, lifecycle.Initialize(true, nil))
+	require.NoError(test, lifecycle.Check(true, nil))
+	require.ErrorIs(test, lifecycle.Initialize(true, nil), szerror.ErrSzBadInput)
+	require.NoError(test, lifecycle.Destroy(true, nil))
+	require.ErrorIs(test, lifecycle.Check(true, nil), szerror.ErrSzNotInitialized)
+	require.ErrorIs(test, lifecycle.Destroy(true, nil), szerror.ErrSzNotInitialized)
+	require.NoError(test, lifecycle.Initialize(true, nil))
+	require.NoError(test, lifecycle.Check(true, nil))
+}
+
+func TestLifecycle_strict_error(test *testing.T) {
+	expectedErr := errors.New("expected")
+	lifecycle := &Lifecycle{}
+	require.ErrorIs(test, lifecycle.Initialize(true, expectedErr), expectedErr)
+	require.ErrorIs(test, lifecycle.Check(true, expectedErr), szerror.ErrSzNotInitialized)
+	require.NoError(test, lifecycle.Initialize(true, nil))
+	require.ErrorIs(test, lifecycle.Check(true, expectedErr), expectedErr)
+	require.ErrorIs(test, lifecycle.Destroy(true, expectedErr), expectedErr)
+	require.NoError(test, lifecycle.Check(true, nil))
+}
+
+func TestLifecycle_notStrict(test *testing.T) {
+	lifecycle := &Lifecycle{}
+	require.NoError(test, lifecycle.Check(false, nil))
+	require.NoError(test, lifecycle.Destroy(false, nil))
+	require.NoError(test, lifecycle.Initialize(false, nil))
+	require.NoError(test, lifecycle.Initialize(false, nil))
+	require.NoError(test, lifecycle.Check(true, nil))
+}
@@ -0,0 +1,171 @@
+package helper
+
+import (
+	"context"
+	"sync"
+
+	"github.com/senzing-garage/go-observing/observer"
+)
+
+/*
+Notifications sends the observer notifications of a mock, tracking those sent asynchronously
+so they can be awaited.  The zero value is ready to use.  It is safe for concurrent use.
+*/
+type Notifications struct {
+	idle    chan struct{}
+	mutex   sync.Mutex
+	pending int
+}
+
+/*
+The Send method delivers a notification.
+
+Input
+  - isSynchronous: Whether to deliver before returning (e.g. Szengine.SynchronousNotifications).
+    Otherwise the notification is delivered in a goroutine.
+  - notification: A function notifying observers.
+*/
+func (notifications *Notifications) Send(isSynchronous bool, notification func()) {
+	if isSynchronous {
+		notification()
+		return
+	}
+	notifications.mutex.Lock()
+	notifications.pending++
+	if notifications.idle == nil {
+		notifications.idle = make(chan struct{})
+	}
+	notifications.mutex.Unlock()
+	go func() {
+		defer notifications.done()
+		notification()
+	}()
+}
+
+/*
+The Wait method waits until the notifications sent asynchronously have been delivered.
+
+Input
+  - ctx: A context to control lifecycle.  Waiting stops when ctx is cancelled or expires.
+
+Output
+  - ctx.Err() if waiting stopped early; otherwise nil.
+*/
+func (notifications *Notifications) Wait(ctx context.Context) error {
+	notifications.mutex.Lock()
+	idle := notifications.idle
+	notifications.mutex.Unlock()
+	if idle == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-idle:
+		return nil
+	}
+}
+
+func (notifications *Notifications) done() {
+	notifications.mutex.Lock()
+	defer notifications.mutex.Unlock()
+	notifications.pending--
+	if notifications.pending == 0 {
+		close(notifications.idle)
+		notifications.idle = nil
+	}
+}
+
+/*
+SynchronousSubject is a subject.Subject notifying its observers in the calling goroutine,
+in the order they were registered.  Unlike subject.SimpleSubject, an observer has received
+a message when NotifyObservers() returns.  It is safe for concurrent use.
+*/
+type SynchronousSubject struct {
+	mutex     sync.RWMutex
+	observers []observer.Observer
+}
+
+/*
+The GetObservers method returns the registered observers.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The observers, in the order they were registered.
+*/
+func (subject *SynchronousSubject) GetObservers(ctx context.Context) []observer.Observer {
+	_ = ctx
+	subject.mutex.RLock()
+	defer subject.mutex.RUnlock()
+	return append([]observer.Observer{}, subject.observers...)
+}
+
+/*
+The HasObservers method tells whether observers are registered.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - True if at least one observer is registered.
+*/
+func (subject *SynchronousSubject) HasObservers(ctx context.Context) bool {
+	_ = ctx
+	subject.mutex.RLock()
+	defer subject.mutex.RUnlock()
+	return len(subject.observers) > 0
+}
+
+/*
+The NotifyObservers method sends a message to each registered observer.
+
+Input
+  - ctx: A context to control lifecycle.
+  - message: The message sent.
+*/
+func (subject *SynchronousSubject) NotifyObservers(ctx context.Context, message string) error {
+	for _, observer := range subject.GetObservers(ctx) {
+		observer.UpdateObserver(ctx, message)
+	}
+	return nil
+}
+
+/*
+The RegisterObserver method adds an observer.  Observers having the same ID are registered once.
+
+Input
+  - ctx: A context to control lifecycle.
+  - observer: The observer to be added.
+*/
+func (subject *SynchronousSubject) RegisterObserver(ctx context.Context, observer observer.Observer) error {
+	subject.mutex.Lock()
+	defer subject.mutex.Unlock()
+	for _, registered := range subject.observers {
+		if registered.GetObserverID(ctx) == observer.GetObserverID(ctx) {
+			return nil
+		}
+	}
+	subject.observers = append(subject.observers, observer)
+	return nil
+}
+
+/*
+The UnregisterObserver method removes the observer having the same ID.
+
+Input
+  - ctx: A context to control lifecycle.
+  - observer: The observer to be removed.
+*/
+func (subject *SynchronousSubject) UnregisterObserver(ctx context.Context, observer observer.Observer) error {
+	subject.mutex.Lock()
+	defer subject.mutex.Unlock()
+	for index, registered := range subject.observers {
+		if registered.GetObserverID(ctx) == observer.GetObserverID(ctx) {
+			subject.observers = append(subject.observers[:index], subject.observers[index+1:]...)
+			break
+		}
+	}
+	return nil
+}
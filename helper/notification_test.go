@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/go-observing/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// An observer that collects its messages.
+type recordingObserver struct {
+	id       string
+	messages []string
+	mutex    sync.Mutex
+}
+
+func (observer *recordingObserver) GetObserverID(ctx context.Context) string {
+	_ = ctx
+	return observer.id
+}
+
+func (observer *recordingObserver) UpdateObserver(ctx context.Context, message string) {
+	_ = ctx
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	observer.messages = append(observer.messages, message)
+}
+
+func (observer *recordingObserver) getMessages() []string {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	return append([]string{}, observer.messages...)
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestNotifications_Send(test *testing.T) {
+	ctx := context.TODO()
+	notifications := &Notifications{}
+	delivered := 0
+	notifications.Send(true, func() { delivered++ })
+	assert.Equal(test, 1, delivered)
+	var mutex sync.Mutex
+	for range make([]struct{}, 100) {
+		notifications.Send(false, func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			delivered++
+		})
+	}
+	require.NoError(test, notifications.Wait(ctx))
+	assert.Equal(test, 101, delivered)
+	require.NoError(test, notifications.Wait(ctx))
+}
+
+func TestNotifications_Wait_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	notifications := &Notifications{}
+	blocked := make(chan struct{})
+	notifications.Send(false, func() { <-blocked })
+	cancel()
+	require.ErrorIs(test, notifications.Wait(ctx), context.Canceled)
+	close(blocked)
+	require.NoError(test, notifications.Wait(context.TODO()))
+}
+
+func TestSynchronousSubject(test *testing.T) {
+	ctx := context.TODO()
+	first := &recordingObserver{id: "Observer 1"}
+	second := &recordingObserver{id: "Observer 2"}
+	var subject SynchronousSubject
+	assert.False(test, subject.HasObservers(ctx))
+	require.NoError(test, subject.RegisterObserver(ctx, first))
+	require.NoError(test, subject.RegisterObserver(ctx, second))
+	require.NoError(test, subject.RegisterObserver(ctx, &recordingObserver{id: "Observer 1"}))
+	assert.Equal(test, []observer.Observer{first, second}, subject.GetObservers(ctx))
+	require.NoError(test, subject.NotifyObservers(ctx, "one"))
+	require.NoError(test, subject.UnregisterObserver(ctx, first))
+	require.NoError(test, subject.NotifyObservers(ctx, "two"))
+	assert.Equal(test, []string{"one"}, first.getMessages())
+	assert.Equal(test, []string{"one", "two"}, second.getMessages())
+	require.NoError(test, subject.UnregisterObserver(ctx, second))
+	assert.False(test, subject.HasObservers(ctx))
+}
@@ -0,0 +1,194 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrResponseQueueExhausted is returned when a drained queue uses QueueFallbackFail.
+var ErrResponseQueueExhausted = errors.New("response queue exhausted")
+
+// QueueFallback determines what a method returns once its queue of Responses is drained.
+type QueueFallback int
+
+const (
+	// QueueFallbackStatic returns the mock's ...Result and ...Error fields.
+	QueueFallbackStatic QueueFallback = iota
+	// QueueFallbackRepeatLast returns the last queued Response again.
+	QueueFallbackRepeatLast
+	// QueueFallbackZero returns the zero value of the result and no error.
+	QueueFallbackZero
+	// QueueFallbackFail returns ErrResponseQueueExhausted.
+	QueueFallbackFail
+)
+
+// Response is a result and error to be returned by a mock method.  Result must have the method's result type.
+type Response struct {
+	Error  error
+	Result interface{}
+}
+
+// ResponseQueues holds an ordered queue of Responses per method.
+// The zero value is ready to use.  It is safe for concurrent use.
+type ResponseQueues struct {
+	mutex  sync.Mutex
+	queues map[string]*responseQueue
+}
+
+type responseQueue struct {
+	fallback  QueueFallback
+	last      *Response
+	responses []Response
+}
+
+/*
+The Enqueue method appends Responses to the queue of a method.
+
+Input
+  - method: The name of the method (e.g. "GetRedoRecord").
+  - responses: The Responses, in the order they are to be returned.
+*/
+func (queues *ResponseQueues) Enqueue(method string, responses ...Response) {
+	queues.mutex.Lock()
+	defer queues.mutex.Unlock()
+	queue := queues.getQueue(method)
+	queue.responses = append(queue.responses, responses...)
+}
+
+/*
+The Len method returns the number of Responses remaining in the queue of a method.
+
+Input
+  - method: The name of the method (e.g. "GetRedoRecord").
+
+Output
+  - The number of Responses not yet returned.
+*/
+func (queues *ResponseQueues) Len(method string) int {
+	queues.mutex.Lock()
+	defer queues.mutex.Unlock()
+	queue, ok := queues.queues[method]
+	if !ok {
+		return 0
+	}
+	return len(queue.responses)
+}
+
+/*
+The Reset method removes all queues and fallbacks.
+*/
+func (queues *ResponseQueues) Reset() {
+	queues.mutex.Lock()
+	defer queues.mutex.Unlock()
+	queues.queues = nil
+}
+
+/*
+The SetFallback method sets what a method returns once its queue is drained.
+
+Input
+  - method: The name of the method (e.g. "GetRedoRecord").
+  - fallback: The behavior once the queue is drained.
+*/
+func (queues *ResponseQueues) SetFallback(method string, fallback QueueFallback) {
+	queues.mutex.Lock()
+	defer queues.mutex.Unlock()
+	queues.getQueue(method).fallback = fallback
+}
+
+// Get the queue for a method, creating it if needed.  The caller holds the mutex.
+func (queues *ResponseQueues) getQueue(method string) *responseQueue {
+	if queues.queues == nil {
+		queues.queues = map[string]*responseQueue{}
+	}
+	queue, ok := queues.queues[method]
+	if !ok {
+		queue = &responseQueue{}
+		queues.queues[method] = queue
+	}
+	return queue
+}
+
+// Take the next Response for a method.  A nil Response means the static fields apply.
+func (queues *ResponseQueues) next(method string) (*Response, error) {
+	if queues == nil {
+		return nil, nil
+	}
+	queues.mutex.Lock()
+	defer queues.mutex.Unlock()
+	queue, ok := queues.queues[method]
+	if !ok {
+		return nil, nil
+	}
+	if len(queue.responses) > 0 {
+		response := queue.responses[0]
+		queue.responses = queue.responses[1:]
+		queue.last = &response
+		return &response, nil
+	}
+	switch queue.fallback {
+	case QueueFallbackRepeatLast:
+		return queue.last, nil
+	case QueueFallbackZero:
+		return &Response{}, nil
+	case QueueFallbackFail:
+		return nil, fmt.Errorf("%s: %w", method, ErrResponseQueueExhausted)
+	case QueueFallbackStatic:
+	}
+	return nil, nil
+}
+
+// ----------------------------------------------------------------------------
+// Functions used by mock objects
+// ----------------------------------------------------------------------------
+
+/*
+The QueuedError function returns the error of the next queued Response of a method, if any.
+
+Input
+  - queues: The mock's ResponseQueues.  May be nil.
+  - method: The name of the method called.
+  - err: The error the mock would otherwise return.
+
+Output
+  - The error to be returned by the mock.
+*/
+func QueuedError(queues *ResponseQueues, method string, err error) error {
+	response, queueErr := queues.next(method)
+	if queueErr != nil {
+		return queueErr
+	}
+	if response == nil {
+		return err
+	}
+	return response.Error
+}
+
+/*
+The QueuedResult function returns the result and error of the next queued Response of a method, if any.
+
+Input
+  - queues: The mock's ResponseQueues.  May be nil.
+  - method: The name of the method called.
+  - result: The result the mock would otherwise return.
+  - err: The error the mock would otherwise return.
+
+Output
+  - The result and error to be returned by the mock.
+*/
+func QueuedResult[T any](queues *ResponseQueues, method string, result T, err error) (T, error) {
+	var zero T
+	response, queueErr := queues.next(method)
+	if queueErr != nil {
+		return zero, queueErr
+	}
+	if response == nil {
+		return result, err
+	}
+	typedResult, convertErr := convertResult[T](response.Result)
+	if convertErr != nil {
+		return zero, fmt.Errorf("%s: %w", method, convertErr)
+	}
+	return typedResult, response.Error
+}
@@ -0,0 +1,101 @@
+package helper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestResponseQueues_Enqueue(test *testing.T) {
+	queues := &ResponseQueues{}
+	expectedErr := fmt.Errorf("expected")
+	queues.Enqueue("GetRedoRecord", Response{Result: "redo-1"}, Response{Result: "redo-2"})
+	queues.Enqueue("GetRedoRecord", Response{Error: expectedErr})
+	assert.Equal(test, 3, queues.Len("GetRedoRecord"))
+	result, err := QueuedResult(queues, "GetRedoRecord", "static", nil)
+	require.NoError(test, err)
+	assert.Equal(test, "redo-1", result)
+	result, err = QueuedResult(queues, "GetRedoRecord", "static", nil)
+	require.NoError(test, err)
+	assert.Equal(test, "redo-2", result)
+	_, err = QueuedResult(queues, "GetRedoRecord", "static", nil)
+	require.ErrorIs(test, err, expectedErr)
+	assert.Equal(test, 0, queues.Len("GetRedoRecord"))
+	assert.Equal(test, 0, queues.Len("CountRedoRecords"))
+}
+
+func TestResponseQueues_fallbackStatic(test *testing.T) {
+	queues := &ResponseQueues{}
+	queues.Enqueue("CountRedoRecords", Response{Result: int64(2)})
+	result, err := QueuedResult(queues, "CountRedoRecords", int64(5), nil)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), result)
+	result, err = QueuedResult(queues, "CountRedoRecords", int64(5), nil)
+	require.NoError(test, err)
+	assert.Equal(test, int64(5), result)
+}
+
+func TestResponseQueues_fallbackRepeatLast(test *testing.T) {
+	queues := &ResponseQueues{}
+	queues.SetFallback("GetRedoRecord", QueueFallbackRepeatLast)
+	queues.Enqueue("GetRedoRecord", Response{Result: "redo-1"})
+	for range []int{1, 2, 3} {
+		result, err := QueuedResult(queues, "GetRedoRecord", "static", nil)
+		require.NoError(test, err)
+		assert.Equal(test, "redo-1", result)
+	}
+}
+
+func TestResponseQueues_fallbackZero(test *testing.T) {
+	queues := &ResponseQueues{}
+	queues.SetFallback("GetRedoRecord", QueueFallbackZero)
+	queues.Enqueue("GetRedoRecord", Response{Result: "redo-1"})
+	_, err := QueuedResult(queues, "GetRedoRecord", "static", nil)
+	require.NoError(test, err)
+	result, err := QueuedResult(queues, "GetRedoRecord", "static", fmt.Errorf("static"))
+	require.NoError(test, err)
+	assert.Equal(test, "", result)
+}
+
+func TestResponseQueues_fallbackFail(test *testing.T) {
+	queues := &ResponseQueues{}
+	queues.SetFallback("Destroy", QueueFallbackFail)
+	queues.Enqueue("Destroy", Response{})
+	require.NoError(test, QueuedError(queues, "Destroy", fmt.Errorf("static")))
+	require.ErrorIs(test, QueuedError(queues, "Destroy", nil), ErrResponseQueueExhausted)
+	_, err := QueuedResult(queues, "Destroy", "static", nil)
+	require.ErrorIs(test, err, ErrResponseQueueExhausted)
+}
+
+func TestResponseQueues_Reset(test *testing.T) {
+	queues := &ResponseQueues{}
+	queues.SetFallback("GetRedoRecord", QueueFallbackFail)
+	queues.Enqueue("GetRedoRecord", Response{Result: "redo-1"})
+	queues.Reset()
+	assert.Equal(test, 0, queues.Len("GetRedoRecord"))
+	result, err := QueuedResult(queues, "GetRedoRecord", "static", nil)
+	require.NoError(test, err)
+	assert.Equal(test, "static", result)
+}
+
+func TestResponseQueues_nil(test *testing.T) {
+	var queues *ResponseQueues
+	result, err := QueuedResult(queues, "GetRedoRecord", "static", nil)
+	require.NoError(test, err)
+	assert.Equal(test, "static", result)
+	expectedErr := fmt.Errorf("expected")
+	require.ErrorIs(test, QueuedError(queues, "Destroy", expectedErr), expectedErr)
+}
+
+func TestResponseQueues_badResult(test *testing.T) {
+	queues := &ResponseQueues{}
+	queues.Enqueue("CountRedoRecords", Response{Result: "five"})
+	_, err := QueuedResult(queues, "CountRedoRecords", int64(5), nil)
+	require.Error(test, err)
+}
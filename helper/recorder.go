@@ -0,0 +1,116 @@
+package helper
+
+import (
+	"sync"
+	"time"
+)
+
+// Call describes a single invocation of a mock method.
+type Call struct {
+	Arguments []interface{}
+	Error     error
+	Flags     int64
+	Method    string
+	Result    interface{}
+	Time      time.Time
+}
+
+// CallRecorder keeps a log of Calls.  It is safe for concurrent use.
+type CallRecorder struct {
+	calls []Call
+	mutex sync.RWMutex
+}
+
+/*
+The Calls method returns a copy of all recorded calls in the order they were made.
+
+Output
+  - The recorded calls.
+*/
+func (recorder *CallRecorder) Calls() []Call {
+	recorder.mutex.RLock()
+	defer recorder.mutex.RUnlock()
+	result := make([]Call, len(recorder.calls))
+	copy(result, recorder.calls)
+	return result
+}
+
+/*
+The CallsTo method returns the recorded calls to a single method.
+
+Input
+  - method: The name of the method (e.g. "AddRecord").
+
+Output
+  - The recorded calls to the method in the order they were made.
+*/
+func (recorder *CallRecorder) CallsTo(method string) []Call {
+	recorder.mutex.RLock()
+	defer recorder.mutex.RUnlock()
+	result := []Call{}
+	for _, call := range recorder.calls {
+		if call.Method == method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+/*
+The Count method returns the number of recorded calls to a method.
+
+Input
+  - method: The name of the method (e.g. "AddRecord").
+
+Output
+  - The number of calls to the method.
+*/
+func (recorder *CallRecorder) Count(method string) int {
+	return len(recorder.CallsTo(method))
+}
+
+/*
+The LastCall method returns the most recent call to a method.
+
+Input
+  - method: The name of the method (e.g. "AddRecord").
+
+Output
+  - The most recent call.
+  - false, if the method has not been called.
+*/
+func (recorder *CallRecorder) LastCall(method string) (Call, bool) {
+	recorder.mutex.RLock()
+	defer recorder.mutex.RUnlock()
+	for i := len(recorder.calls) - 1; i >= 0; i-- {
+		if recorder.calls[i].Method == method {
+			return recorder.calls[i], true
+		}
+	}
+	return Call{}, false
+}
+
+/*
+The Record method appends a call to the log.
+If call.Time is not set, the current time is used.
+
+Input
+  - call: The call to be recorded.
+*/
+func (recorder *CallRecorder) Record(call Call) {
+	if call.Time.IsZero() {
+		call.Time = time.Now()
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.calls = append(recorder.calls, call)
+}
+
+/*
+The Reset method removes all recorded calls.
+*/
+func (recorder *CallRecorder) Reset() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.calls = nil
+}
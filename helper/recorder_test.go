@@ -0,0 +1,36 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestCallRecorder_Record(test *testing.T) {
+	recorder := &CallRecorder{}
+	recorder.Record(Call{Method: "AddRecord", Arguments: []interface{}{"CUSTOMERS", "1001"}})
+	recorder.Record(Call{Method: "DeleteRecord", Arguments: []interface{}{"CUSTOMERS", "1001"}})
+	recorder.Record(Call{Method: "AddRecord", Arguments: []interface{}{"CUSTOMERS", "1002"}})
+	assert.Len(test, recorder.Calls(), 3)
+	assert.Len(test, recorder.CallsTo("AddRecord"), 2)
+	assert.Equal(test, 1, recorder.Count("DeleteRecord"))
+	assert.Equal(test, 0, recorder.Count("GetRecord"))
+	lastCall, ok := recorder.LastCall("AddRecord")
+	require.True(test, ok)
+	assert.Equal(test, "1002", lastCall.Arguments[1])
+	assert.False(test, lastCall.Time.IsZero())
+}
+
+func TestCallRecorder_Reset(test *testing.T) {
+	recorder := &CallRecorder{}
+	recorder.Record(Call{Method: "AddRecord"})
+	recorder.Reset()
+	assert.Empty(test, recorder.Calls())
+	_, ok := recorder.LastCall("AddRecord")
+	assert.False(test, ok)
+}
@@ -0,0 +1,123 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+)
+
+// SzErrorTemplate describes a Senzing error code and its message.
+// Details, if not empty, formats the values given to NewSzErrorFromCatalog() with one %v per value
+// and is appended to Message.
+// Errors lists szerror types the error matches in addition to those sz-sdk-go derives from Code.
+type SzErrorTemplate struct {
+	Code    int
+	Details string
+	Errors  []error
+	Message string
+}
+
+// Names of the entries in SzErrorCatalog.
+const (
+	SzErrorBadInput               = "BadInput"
+	SzErrorConfigNotFound         = "ConfigNotFound"
+	SzErrorConfiguration          = "Configuration"
+	SzErrorDatabase               = "Database"
+	SzErrorDatabaseConnectionLost = "DatabaseConnectionLost"
+	SzErrorEntityNotFound         = "EntityNotFound"
+	SzErrorLicense                = "License"
+	SzErrorNotInitialized         = "NotInitialized"
+	SzErrorRecordNotFound         = "RecordNotFound"
+	SzErrorReplaceConflict        = "ReplaceConflict"
+	SzErrorUnknownDataSource      = "UnknownDataSource"
+)
+
+/*
+SzErrorCatalog maps a name to a commonly seen Senzing error.
+The error code determines which szerror types the resulting error matches.
+The details each entry expects are in parentheses:
+
+  - BadInput (reason): szerror.ErrSzBadInput
+  - ConfigNotFound (configID): szerror.ErrSzConfiguration
+  - Configuration: szerror.ErrSzConfiguration
+  - Database (reason): szerror.ErrSzDatabase, szerror.ErrSzUnrecoverable
+  - DatabaseConnectionLost: szerror.ErrSzDatabaseConnectionLost, szerror.ErrSzRetryable
+  - EntityNotFound (entityID): szerror.ErrSzNotFound, szerror.ErrSzBadInput
+  - License: szerror.ErrSzLicense, szerror.ErrSzUnrecoverable
+  - NotInitialized: szerror.ErrSzNotInitialized, szerror.ErrSzUnrecoverable
+  - RecordNotFound (dataSourceCode, recordID): szerror.ErrSzNotFound, szerror.ErrSzBadInput
+  - ReplaceConflict (reason): szerror.ErrSzConfiguration
+  - UnknownDataSource (dataSourceCode): szerror.ErrSzUnknownDataSource, szerror.ErrSzBadInput
+
+sz-sdk-go does not classify the code of UnknownDataSource, so its szerror types are listed in Errors.
+*/
+var SzErrorCatalog = map[string]SzErrorTemplate{
+	SzErrorBadInput:               {Code: 2, Details: ": %v", Message: "Invalid input"},
+	SzErrorConfigNotFound:         {Code: 7221, Details: " with data ID [%v].", Message: "No engine configuration registered"},
+	SzErrorConfiguration:          {Code: 7220, Message: "No engine configuration registered in datastore"},
+	SzErrorDatabase:               {Code: 1001, Details: ": %v", Message: "Database error"},
+	SzErrorDatabaseConnectionLost: {Code: 1007, Message: "Database connection lost"},
+	SzErrorEntityNotFound:         {Code: 37, Details: " '%v'", Message: "Unknown resolved entity value"},
+	SzErrorLicense:                {Code: 9000, Message: "License has expired"},
+	SzErrorNotInitialized:         {Code: 48, Message: "Not initialized"},
+	SzErrorRecordNotFound:         {Code: 33, Details: ": dsrc[%v], record[%v]", Message: "Unknown record"},
+	SzErrorReplaceConflict:        {Code: 7245, Details: ": %v", Message: "Current configuration ID does not match specified data ID"},
+	SzErrorUnknownDataSource: {
+		Code:    27,
+		Details: " '%v'",
+		Errors:  []error{szerror.ErrSzUnknownDataSource, szerror.ErrSzBadInput},
+		Message: "Unknown DATA_SOURCE value",
+	},
+}
+
+/*
+The NewSzError function creates an error having the same types as the Senzing SDK would
+return for the Senzing error code.
+
+Input
+  - errorCode: A Senzing error code (e.g. 33 for "Unknown record").
+  - message: The text of the error.
+
+Output
+  - An error that can be inspected with errors.Is() and the szerror package.
+*/
+func NewSzError(errorCode int, message string) error {
+	return szerror.New(errorCode, fmt.Sprintf("SENZ%04d|%s", errorCode, message))
+}
+
+/*
+The NewSzErrorFromCatalog function creates an error from an entry in SzErrorCatalog.
+
+Input
+  - name: The name of the entry in SzErrorCatalog (e.g. SzErrorRecordNotFound).
+  - details: Values formatted into the message of the entry, as listed in SzErrorCatalog.
+    Without details, the message is the plain Message of the entry.
+    Extra values are ignored and missing values are empty.
+
+Output
+  - An error that can be inspected with errors.Is() and the szerror package.
+*/
+func NewSzErrorFromCatalog(name string, details ...interface{}) error {
+	template, ok := SzErrorCatalog[name]
+	if !ok {
+		return fmt.Errorf("unknown Senzing error name: %s", name)
+	}
+	message := template.Message
+	if verbs := strings.Count(template.Details, "%v"); len(details) > 0 && verbs > 0 {
+		values := make([]interface{}, verbs)
+		for index := range values {
+			values[index] = ""
+			if index < len(details) {
+				values[index] = details[index]
+			}
+		}
+		message += fmt.Sprintf(template.Details, values...)
+	}
+	err := NewSzError(template.Code, message)
+	if len(template.Errors) > 0 {
+		return errors.Join(append(append([]error{}, template.Errors...), err)...)
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestHelpers_NewSzError(test *testing.T) {
+	err := NewSzError(33, "Unknown record: dsrc[CUSTOMERS], record[1001]")
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	assert.Equal(test, 33, szerror.Code(err.Error()))
+	assert.Contains(test, err.Error(), "SENZ0033|")
+}
+
+func TestHelpers_NewSzErrorFromCatalog(test *testing.T) {
+	testCases := map[string]error{
+		SzErrorConfigNotFound:         szerror.ErrSzConfiguration,
+		SzErrorDatabaseConnectionLost: szerror.ErrSzRetryable,
+		SzErrorLicense:                szerror.ErrSzLicense,
+		SzErrorRecordNotFound:         szerror.ErrSzNotFound,
+		SzErrorReplaceConflict:        szerror.ErrSzConfiguration,
+		SzErrorUnknownDataSource:      szerror.ErrSzBadInput,
+	}
+	for name, expected := range testCases {
+		err := NewSzErrorFromCatalog(name, "CUSTOMERS", "1001")
+		require.ErrorIs(test, err, expected, name)
+	}
+	err := NewSzErrorFromCatalog(SzErrorRecordNotFound, "CUSTOMERS", "1001")
+	assert.Contains(test, err.Error(), "Unknown record: dsrc[CUSTOMERS], record[1001]")
+}
+
+func TestHelpers_NewSzErrorFromCatalog_catalog(test *testing.T) {
+	for name, template := range SzErrorCatalog {
+		err := NewSzErrorFromCatalog(name, "CUSTOMERS", "1001")
+		assert.Equal(test, template.Code, szerror.Code(err.Error()), name)
+		assert.NotErrorIs(test, err, szerror.ErrSzBase, name)
+	}
+}
+
+func TestHelpers_NewSzErrorFromCatalog_details(test *testing.T) {
+	for name, template := range SzErrorCatalog {
+		err := NewSzErrorFromCatalog(name)
+		assert.True(test, strings.HasSuffix(err.Error(), "|"+template.Message), err.Error())
+		assert.NotContains(test, NewSzErrorFromCatalog(name, "CUSTOMERS").Error(), "%!", name)
+	}
+	err := NewSzErrorFromCatalog(SzErrorRecordNotFound, "CUSTOMERS")
+	assert.Contains(test, err.Error(), "Unknown record: dsrc[CUSTOMERS], record[]")
+}
+
+func TestHelpers_NewSzErrorFromCatalog_unknownDataSource(test *testing.T) {
+	err := NewSzErrorFromCatalog(SzErrorUnknownDataSource, "CUSTOMERS")
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Equal(test, 27, szerror.Code(err.Error()))
+	assert.Contains(test, err.Error(), "Unknown DATA_SOURCE value 'CUSTOMERS'")
+}
+
+func TestHelpers_NewSzErrorFromCatalog_badName(test *testing.T) {
+	err := NewSzErrorFromCatalog("BadName")
+	require.Error(test, err)
+	assert.NotErrorIs(test, err, szerror.ErrSzBase)
+}
@@ -0,0 +1,195 @@
+package helper
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ResponseTables holds Responses per method, keyed by the identifying arguments of a call.
+// The zero value is ready to use.  It is safe for concurrent use.
+//
+// The keys used by the mock objects are:
+//
+//   - Szconfigmanager.GetConfig: configID
+//   - Szdiagnostic.GetFeature: featureID
+//   - Szengine.FindInterestingEntitiesByEntityID: entityID
+//   - Szengine.FindInterestingEntitiesByRecordID: dataSourceCode, recordID
+//   - Szengine.FindNetworkByEntityID: entityIDs
+//   - Szengine.FindNetworkByRecordID: recordKeys
+//   - Szengine.FindPathByEntityID: startEntityID, endEntityID
+//   - Szengine.FindPathByRecordID: startDataSourceCode, startRecordID, endDataSourceCode, endRecordID
+//   - Szengine.GetEntityByEntityID: entityID
+//   - Szengine.GetEntityByRecordID: dataSourceCode, recordID
+//   - Szengine.GetRecord: dataSourceCode, recordID
+//   - Szengine.GetVirtualEntityByRecordID: recordKeys
+//   - Szengine.HowEntityByEntityID: entityID
+//   - Szengine.SearchByAttributes: attributes
+//   - Szengine.WhyEntities: entityID1, entityID2
+//   - Szengine.WhyRecordInEntity: dataSourceCode, recordID
+//   - Szengine.WhyRecords: dataSourceCode1, recordID1, dataSourceCode2, recordID2
+type ResponseTables struct {
+	mutex  sync.Mutex
+	tables map[string]*responseTable
+}
+
+type responseTable struct {
+	defaultResponse *Response
+	entries         []tableEntry
+	notFound        string
+}
+
+type tableEntry struct {
+	matchers []Matcher
+	response Response
+}
+
+/*
+The Reset method removes all tables.
+*/
+func (tables *ResponseTables) Reset() {
+	tables.mutex.Lock()
+	defer tables.mutex.Unlock()
+	tables.tables = nil
+}
+
+/*
+The Set method adds a Response for a key to the table of a method.
+Key values that are not Matchers are matched with Eq().
+Entries are tried in the order they were added.
+
+Input
+  - method: The name of the method (e.g. "GetEntityByRecordID").
+  - response: The Response returned for the key.
+  - key: The identifying arguments of the call (e.g. "CUSTOMERS", "1001").
+*/
+func (tables *ResponseTables) Set(method string, response Response, key ...interface{}) {
+	entry := tableEntry{
+		response: response,
+	}
+	for _, value := range key {
+		matcher, ok := value.(Matcher)
+		if !ok {
+			matcher = Eq(value)
+		}
+		entry.matchers = append(entry.matchers, matcher)
+	}
+	tables.mutex.Lock()
+	defer tables.mutex.Unlock()
+	table := tables.getTable(method)
+	table.entries = append(table.entries, entry)
+}
+
+/*
+The SetDefault method sets the Response of a method for keys not in its table.
+
+Input
+  - method: The name of the method (e.g. "GetEntityByRecordID").
+  - response: The Response returned for unmatched keys.
+*/
+func (tables *ResponseTables) SetDefault(method string, response Response) {
+	tables.mutex.Lock()
+	defer tables.mutex.Unlock()
+	tables.getTable(method).defaultResponse = &response
+}
+
+/*
+The SetNotFound method makes a method return an error for keys not in its table.
+The error is created with NewSzErrorFromCatalog(), using the key as details.
+SetNotFound takes precedence over SetDefault.
+
+Input
+  - method: The name of the method (e.g. "GetEntityByRecordID").
+  - errorName: The name of an entry in SzErrorCatalog (e.g. SzErrorRecordNotFound).
+    An empty string removes the setting.
+*/
+func (tables *ResponseTables) SetNotFound(method string, errorName string) {
+	tables.mutex.Lock()
+	defer tables.mutex.Unlock()
+	tables.getTable(method).notFound = errorName
+}
+
+// Get the table for a method, creating it if needed.  The caller holds the mutex.
+func (tables *ResponseTables) getTable(method string) *responseTable {
+	if tables.tables == nil {
+		tables.tables = map[string]*responseTable{}
+	}
+	table, ok := tables.tables[method]
+	if !ok {
+		table = &responseTable{}
+		tables.tables[method] = table
+	}
+	return table
+}
+
+// Look up the Response for a key.  A nil Response means the static fields apply.
+func (tables *ResponseTables) lookup(method string, key []interface{}) (*Response, error) {
+	if tables == nil {
+		return nil, nil
+	}
+	tables.mutex.Lock()
+	defer tables.mutex.Unlock()
+	table, ok := tables.tables[method]
+	if !ok {
+		return nil, nil
+	}
+	for _, entry := range table.entries {
+		if entry.matches(key) {
+			response := entry.response
+			return &response, nil
+		}
+	}
+	if len(table.notFound) > 0 {
+		return nil, NewSzErrorFromCatalog(table.notFound, key...)
+	}
+	return table.defaultResponse, nil
+}
+
+func (entry tableEntry) matches(key []interface{}) bool {
+	if len(entry.matchers) != len(key) {
+		return false
+	}
+	for i, matcher := range entry.matchers {
+		if !matcher.Matches(key[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ----------------------------------------------------------------------------
+// Functions used by mock objects
+// ----------------------------------------------------------------------------
+
+/*
+The TableResult function returns the result and error of the table entry matching the key, if any.
+An error the mock would otherwise return (e.g. from XError fields, Chaos or Triggers) takes precedence
+over the table, which is not consulted.
+
+Input
+  - tables: The mock's ResponseTables.  May be nil.
+  - method: The name of the method called.
+  - result: The result the mock would otherwise return.
+  - err: The error the mock would otherwise return.
+  - key: The identifying arguments of the call.
+
+Output
+  - The result and error to be returned by the mock.
+*/
+func TableResult[T any](tables *ResponseTables, method string, result T, err error, key ...interface{}) (T, error) {
+	var zero T
+	if err != nil {
+		return result, err
+	}
+	response, tableErr := tables.lookup(method, key)
+	if tableErr != nil {
+		return zero, tableErr
+	}
+	if response == nil {
+		return result, err
+	}
+	typedResult, convertErr := convertResult[T](response.Result)
+	if convertErr != nil {
+		return zero, fmt.Errorf("%s: %w", method, convertErr)
+	}
+	return typedResult, response.Error
+}
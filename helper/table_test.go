@@ -0,0 +1,107 @@
+package helper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestResponseTables_Set(test *testing.T) {
+	tables := &ResponseTables{}
+	expectedErr := fmt.Errorf("expected")
+	tables.Set("GetEntityByRecordID", Response{Result: `{"ENTITY_ID":1}`}, "CUSTOMERS", "1001")
+	tables.Set("GetEntityByRecordID", Response{Result: `{"ENTITY_ID":2}`}, "CUSTOMERS", "1002")
+	tables.Set("GetEntityByRecordID", Response{Error: expectedErr}, "CUSTOMERS", Regex(`^9`))
+	result, err := TableResult(tables, "GetEntityByRecordID", "static", nil, "CUSTOMERS", "1001")
+	require.NoError(test, err)
+	assert.Equal(test, `{"ENTITY_ID":1}`, result)
+	result, err = TableResult(tables, "GetEntityByRecordID", "static", nil, "CUSTOMERS", "1002")
+	require.NoError(test, err)
+	assert.Equal(test, `{"ENTITY_ID":2}`, result)
+	_, err = TableResult(tables, "GetEntityByRecordID", "static", nil, "CUSTOMERS", "9999")
+	require.ErrorIs(test, err, expectedErr)
+	result, err = TableResult(tables, "GetEntityByRecordID", "static", nil, "CUSTOMERS", "1003")
+	require.NoError(test, err)
+	assert.Equal(test, "static", result)
+}
+
+func TestResponseTables_Set_error(test *testing.T) {
+	tables := &ResponseTables{}
+	expectedErr := fmt.Errorf("expected")
+	tables.Set("GetEntityByRecordID", Response{Result: `{"ENTITY_ID":1}`}, "CUSTOMERS", "1001")
+	tables.SetNotFound("GetEntityByRecordID", SzErrorRecordNotFound)
+	result, err := TableResult(tables, "GetEntityByRecordID", "static", expectedErr, "CUSTOMERS", "1001")
+	require.ErrorIs(test, err, expectedErr)
+	assert.Equal(test, "static", result)
+	_, err = TableResult(tables, "GetEntityByRecordID", "static", expectedErr, "CUSTOMERS", "1002")
+	require.ErrorIs(test, err, expectedErr)
+}
+
+func TestResponseTables_Set_entityIDs(test *testing.T) {
+	tables := &ResponseTables{}
+	tables.Set("WhyEntities", Response{Result: `{"WHY_RESULTS":[]}`}, 1, 2)
+	result, err := TableResult(tables, "WhyEntities", "static", nil, int64(1), int64(2))
+	require.NoError(test, err)
+	assert.Equal(test, `{"WHY_RESULTS":[]}`, result)
+	result, err = TableResult(tables, "WhyEntities", "static", nil, int64(2), int64(1))
+	require.NoError(test, err)
+	assert.Equal(test, "static", result)
+}
+
+func TestResponseTables_SetDefault(test *testing.T) {
+	tables := &ResponseTables{}
+	tables.Set("GetEntityByEntityID", Response{Result: `{"ENTITY_ID":1}`}, 1)
+	tables.SetDefault("GetEntityByEntityID", Response{Result: "{}"})
+	result, err := TableResult(tables, "GetEntityByEntityID", "static", nil, int64(1))
+	require.NoError(test, err)
+	assert.Equal(test, `{"ENTITY_ID":1}`, result)
+	result, err = TableResult(tables, "GetEntityByEntityID", "static", nil, int64(2))
+	require.NoError(test, err)
+	assert.Equal(test, "{}", result)
+}
+
+func TestResponseTables_SetNotFound(test *testing.T) {
+	tables := &ResponseTables{}
+	tables.Set("GetRecord", Response{Result: "{}"}, "CUSTOMERS", "1001")
+	tables.SetDefault("GetRecord", Response{Result: "default"})
+	tables.SetNotFound("GetRecord", SzErrorRecordNotFound)
+	_, err := TableResult(tables, "GetRecord", "static", nil, "CUSTOMERS", "1001")
+	require.NoError(test, err)
+	_, err = TableResult(tables, "GetRecord", "static", nil, "CUSTOMERS", "1003")
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "dsrc[CUSTOMERS], record[1003]")
+	tables.SetNotFound("GetRecord", "")
+	result, err := TableResult(tables, "GetRecord", "static", nil, "CUSTOMERS", "1003")
+	require.NoError(test, err)
+	assert.Equal(test, "default", result)
+}
+
+func TestResponseTables_SetNotFound_pair(test *testing.T) {
+	tables := &ResponseTables{}
+	tables.SetNotFound("WhyEntities", SzErrorEntityNotFound)
+	_, err := TableResult(tables, "WhyEntities", "static", nil, int64(1), int64(2))
+	require.Error(test, err)
+	assert.NotContains(test, err.Error(), "EXTRA")
+}
+
+func TestResponseTables_Reset(test *testing.T) {
+	tables := &ResponseTables{}
+	tables.Set("GetFeature", Response{Result: "{}"}, 1)
+	tables.Reset()
+	result, err := TableResult(tables, "GetFeature", "static", nil, int64(1))
+	require.NoError(test, err)
+	assert.Equal(test, "static", result)
+}
+
+func TestResponseTables_nil(test *testing.T) {
+	var tables *ResponseTables
+	result, err := TableResult(tables, "GetFeature", "static", nil, int64(1))
+	require.NoError(test, err)
+	assert.Equal(test, "static", result)
+}
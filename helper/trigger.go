@@ -0,0 +1,223 @@
+package helper
+
+import (
+	"sync"
+	"time"
+)
+
+// A Trigger decides whether a call to a method fails.
+type Trigger interface {
+	Fires(call int, elapsed time.Duration) bool
+}
+
+type afterCallsTrigger struct {
+	calls int
+}
+
+func (trigger afterCallsTrigger) Fires(call int, elapsed time.Duration) bool {
+	_ = elapsed
+	return call > trigger.calls
+}
+
+type afterDurationTrigger struct {
+	duration time.Duration
+}
+
+func (trigger afterDurationTrigger) Fires(call int, elapsed time.Duration) bool {
+	_ = call
+	return elapsed >= trigger.duration
+}
+
+type betweenCallsTrigger struct {
+	first int
+	last  int
+}
+
+func (trigger betweenCallsTrigger) Fires(call int, elapsed time.Duration) bool {
+	_ = elapsed
+	return call >= trigger.first && call <= trigger.last
+}
+
+type everyCallTrigger struct {
+	period int
+}
+
+func (trigger everyCallTrigger) Fires(call int, elapsed time.Duration) bool {
+	_ = elapsed
+	return trigger.period > 0 && call%trigger.period == 0
+}
+
+/*
+The AfterCalls function returns a Trigger firing on every call after the first n calls.
+
+Input
+  - n: The number of calls that succeed.
+*/
+func AfterCalls(n int) Trigger {
+	return afterCallsTrigger{calls: n}
+}
+
+/*
+The AfterDuration function returns a Trigger firing on every call once a duration has passed
+since the Triggers were created or reset.
+
+Input
+  - duration: The time during which calls succeed.
+*/
+func AfterDuration(duration time.Duration) Trigger {
+	return afterDurationTrigger{duration: duration}
+}
+
+/*
+The BetweenCalls function returns a Trigger firing on calls first through last, counting from 1.
+
+Input
+  - first: The first call that fails.
+  - last: The last call that fails.
+*/
+func BetweenCalls(first int, last int) Trigger {
+	return betweenCallsTrigger{first: first, last: last}
+}
+
+/*
+The EveryCall function returns a Trigger firing on every nth call (n, 2n, 3n, ...).
+
+Input
+  - n: The period.  Must be positive.
+*/
+func EveryCall(n int) Trigger {
+	return everyCallTrigger{period: n}
+}
+
+/*
+The OnCall function returns a Trigger firing on the nth call only, counting from 1.
+
+Input
+  - n: The call that fails.
+*/
+func OnCall(n int) Trigger {
+	return betweenCallsTrigger{first: n, last: n}
+}
+
+type triggerRule struct {
+	err     error
+	trigger Trigger
+}
+
+// Triggers fail calls to methods of a mock when their Trigger fires.  It is safe for concurrent use.
+type Triggers struct {
+	calls map[string]int
+	mutex sync.Mutex
+	rules map[string][]triggerRule
+	start time.Time
+}
+
+/*
+The NewTriggers function creates Triggers without rules.  The clock of AfterDuration starts now.
+
+Output
+  - Triggers to be assigned to the Triggers field of a mock.
+*/
+func NewTriggers() *Triggers {
+	return &Triggers{
+		calls: map[string]int{},
+		rules: map[string][]triggerRule{},
+		start: time.Now(),
+	}
+}
+
+/*
+The Add method fails calls to a method with an error when a Trigger fires.
+When several rules of a method fire, the first added applies.
+
+Input
+  - method: The name of the method (e.g. "AddRecord").
+  - trigger: When calls fail.
+  - err: The error returned by the failing calls.  If nil, calls return the error they would otherwise return.
+*/
+func (triggers *Triggers) Add(method string, trigger Trigger, err error) {
+	triggers.mutex.Lock()
+	defer triggers.mutex.Unlock()
+	triggers.rules[method] = append(triggers.rules[method], triggerRule{err: err, trigger: trigger})
+}
+
+/*
+The Calls method returns the number of calls made to a method since the Triggers were created or reset.
+
+Input
+  - method: The name of the method (e.g. "AddRecord").
+
+Output
+  - The number of calls.
+*/
+func (triggers *Triggers) Calls(method string) int {
+	triggers.mutex.Lock()
+	defer triggers.mutex.Unlock()
+	return triggers.calls[method]
+}
+
+/*
+The Clear method removes all rules and resets the Triggers.
+*/
+func (triggers *Triggers) Clear() {
+	triggers.mutex.Lock()
+	defer triggers.mutex.Unlock()
+	triggers.rules = map[string][]triggerRule{}
+	triggers.reset()
+}
+
+/*
+The Reset method sets the call counts to zero and restarts the clock of AfterDuration.
+Rules are kept.
+*/
+func (triggers *Triggers) Reset() {
+	triggers.mutex.Lock()
+	defer triggers.mutex.Unlock()
+	triggers.reset()
+}
+
+// Count a call and return the error of the first rule firing.
+func (triggers *Triggers) fire(method string) (bool, error) {
+	triggers.mutex.Lock()
+	defer triggers.mutex.Unlock()
+	triggers.calls[method]++
+	call := triggers.calls[method]
+	elapsed := time.Since(triggers.start)
+	for _, rule := range triggers.rules[method] {
+		if rule.trigger.Fires(call, elapsed) {
+			return true, rule.err
+		}
+	}
+	return false, nil
+}
+
+// The caller holds the mutex.
+func (triggers *Triggers) reset() {
+	triggers.calls = map[string]int{}
+	triggers.start = time.Now()
+}
+
+// ----------------------------------------------------------------------------
+// Functions used by mock objects
+// ----------------------------------------------------------------------------
+
+/*
+The TriggeredError function counts a call and fails it if a Trigger fires.
+
+Input
+  - triggers: The mock's Triggers.  May be nil.
+  - method: The name of the method called.
+  - err: The error the mock would otherwise return.
+
+Output
+  - The error of the rule that fired, or err if no rule fired or its error is nil.
+*/
+func TriggeredError(triggers *Triggers, method string, err error) error {
+	if triggers == nil {
+		return err
+	}
+	if ok, triggeredErr := triggers.fire(method); ok && triggeredErr != nil {
+		return triggeredErr
+	}
+	return err
+}
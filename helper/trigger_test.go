@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestTriggers_TriggeredError(test *testing.T) {
+	testCases := []struct {
+		name     string
+		trigger  Trigger
+		failures []int
+	}{
+		{name: "AfterCalls", trigger: AfterCalls(7), failures: []int{8, 9, 10}},
+		{name: "BetweenCalls", trigger: BetweenCalls(3, 5), failures: []int{3, 4, 5}},
+		{name: "EveryCall", trigger: EveryCall(3), failures: []int{3, 6, 9}},
+		{name: "OnCall", trigger: OnCall(5), failures: []int{5}},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			expectedErr := errors.New("expected")
+			triggers := NewTriggers()
+			triggers.Add("AddRecord", testCase.trigger, expectedErr)
+			failures := []int{}
+			for call := 1; call <= 10; call++ {
+				if err := TriggeredError(triggers, "AddRecord", nil); err != nil {
+					require.ErrorIs(test, err, expectedErr)
+					failures = append(failures, call)
+				}
+				require.NoError(test, TriggeredError(triggers, "GetRecord", nil))
+			}
+			assert.Equal(test, testCase.failures, failures)
+			assert.Equal(test, 10, triggers.Calls("AddRecord"))
+		})
+	}
+}
+
+func TestTriggers_AfterDuration(test *testing.T) {
+	expectedErr := errors.New("expected")
+	triggers := NewTriggers()
+	triggers.Add("GetRecord", AfterDuration(10*time.Millisecond), expectedErr)
+	require.NoError(test, TriggeredError(triggers, "GetRecord", nil))
+	time.Sleep(10 * time.Millisecond)
+	require.ErrorIs(test, TriggeredError(triggers, "GetRecord", nil), expectedErr)
+	triggers.Reset()
+	require.NoError(test, TriggeredError(triggers, "GetRecord", nil))
+}
+
+func TestTriggers_Add_order(test *testing.T) {
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+	triggers := NewTriggers()
+	triggers.Add("AddRecord", OnCall(2), firstErr)
+	triggers.Add("AddRecord", AfterCalls(1), secondErr)
+	require.NoError(test, TriggeredError(triggers, "AddRecord", nil))
+	require.ErrorIs(test, TriggeredError(triggers, "AddRecord", nil), firstErr)
+	require.ErrorIs(test, TriggeredError(triggers, "AddRecord", nil), secondErr)
+}
+
+func TestTriggers_Add_nilError(test *testing.T) {
+	expectedErr := errors.New("expected")
+	triggers := NewTriggers()
+	triggers.Add("GetRecord", EveryCall(1), nil)
+	require.ErrorIs(test, TriggeredError(triggers, "GetRecord", expectedErr), expectedErr)
+	require.NoError(test, TriggeredError(triggers, "GetRecord", nil))
+}
+
+func TestTriggers_Clear(test *testing.T) {
+	expectedErr := errors.New("expected")
+	triggers := NewTriggers()
+	triggers.Add("AddRecord", AfterCalls(0), expectedErr)
+	require.ErrorIs(test, TriggeredError(triggers, "AddRecord", nil), expectedErr)
+	triggers.Clear()
+	assert.Equal(test, 0, triggers.Calls("AddRecord"))
+	require.NoError(test, TriggeredError(triggers, "AddRecord", nil))
+}
+
+func TestTriggers_Reset(test *testing.T) {
+	expectedErr := errors.New("expected")
+	triggers := NewTriggers()
+	triggers.Add("AddRecord", OnCall(2), expectedErr)
+	require.NoError(test, TriggeredError(triggers, "AddRecord", nil))
+	triggers.Reset()
+	require.NoError(test, TriggeredError(triggers, "AddRecord", nil))
+	require.ErrorIs(test, TriggeredError(triggers, "AddRecord", nil), expectedErr)
+}
+
+func TestTriggers_concurrent(test *testing.T) {
+	expectedErr := errors.New("expected")
+	triggers := NewTriggers()
+	triggers.Add("AddRecord", OnCall(500), expectedErr)
+	var (
+		failures  int
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+	)
+	for range make([]struct{}, 10) {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for range make([]struct{}, 100) {
+				if TriggeredError(triggers, "AddRecord", nil) != nil {
+					mutex.Lock()
+					failures++
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	waitGroup.Wait()
+	assert.Equal(test, 1, failures)
+	assert.Equal(test, 1000, triggers.Calls("AddRecord"))
+}
+
+func TestTriggers_nil(test *testing.T) {
+	expectedErr := errors.New("expected")
+	require.ErrorIs(test, TriggeredError(nil, "AddRecord", expectedErr), expectedErr)
+}
@@ -3,6 +3,7 @@ package szabstractfactory
 import (
 	"context"
 
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
 	"github.com/senzing-garage/sz-sdk-go-mock/szconfig"
 	"github.com/senzing-garage/sz-sdk-go-mock/szconfigmanager"
 	"github.com/senzing-garage/sz-sdk-go-mock/szdiagnostic"
@@ -12,7 +13,11 @@ import (
 )
 
 // Szabstractfactory is an implementation of the senzing.SzAbstractFactory interface.
+// Settings of the factory are shared by the objects it creates.
 type Szabstractfactory struct {
+	Chaos                    *helper.Chaos
+	Latencies                *helper.Latencies
+	SynchronousNotifications bool
 }
 
 // ----------------------------------------------------------------------------
@@ -32,7 +37,11 @@ Output
 */
 func (factory *Szabstractfactory) CreateSzConfig(ctx context.Context) (senzing.SzConfig, error) {
 	_ = ctx
-	result := &szconfig.Szconfig{}
+	result := &szconfig.Szconfig{
+		Chaos:                    factory.Chaos,
+		Latencies:                factory.Latencies,
+		SynchronousNotifications: factory.SynchronousNotifications,
+	}
 	return result, nil
 }
 
@@ -49,7 +58,11 @@ Output
 */
 func (factory *Szabstractfactory) CreateSzConfigManager(ctx context.Context) (senzing.SzConfigManager, error) {
 	_ = ctx
-	result := &szconfigmanager.Szconfigmanager{}
+	result := &szconfigmanager.Szconfigmanager{
+		Chaos:                    factory.Chaos,
+		Latencies:                factory.Latencies,
+		SynchronousNotifications: factory.SynchronousNotifications,
+	}
 	return result, nil
 }
 
@@ -66,7 +79,11 @@ Output
 */
 func (factory *Szabstractfactory) CreateSzDiagnostic(ctx context.Context) (senzing.SzDiagnostic, error) {
 	_ = ctx
-	result := &szdiagnostic.Szdiagnostic{}
+	result := &szdiagnostic.Szdiagnostic{
+		Chaos:                    factory.Chaos,
+		Latencies:                factory.Latencies,
+		SynchronousNotifications: factory.SynchronousNotifications,
+	}
 	return result, nil
 }
 
@@ -83,7 +100,11 @@ Output
 */
 func (factory *Szabstractfactory) CreateSzEngine(ctx context.Context) (senzing.SzEngine, error) {
 	_ = ctx
-	result := &szengine.Szengine{}
+	result := &szengine.Szengine{
+		Chaos:                    factory.Chaos,
+		Latencies:                factory.Latencies,
+		SynchronousNotifications: factory.SynchronousNotifications,
+	}
 	return result, nil
 }
 
@@ -100,6 +121,10 @@ Output
 */
 func (factory *Szabstractfactory) CreateSzProduct(ctx context.Context) (senzing.SzProduct, error) {
 	_ = ctx
-	result := &szproduct.Szproduct{}
+	result := &szproduct.Szproduct{
+		Chaos:                    factory.Chaos,
+		Latencies:                factory.Latencies,
+		SynchronousNotifications: factory.SynchronousNotifications,
+	}
 	return result, nil
 }
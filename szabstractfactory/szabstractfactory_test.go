@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	truncator "github.com/aquilax/truncate"
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/szengine"
+	"github.com/senzing-garage/sz-sdk-go-mock/szproduct"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -79,6 +84,60 @@ func TestSzAbstractFactory_CreateSzProduct(test *testing.T) {
 	printActual(test, version)
 }
 
+func TestSzAbstractFactory_Chaos(test *testing.T) {
+	ctx := context.TODO()
+	chaos := helper.NewChaos(1, helper.ChaosSettings{FailureRate: 1})
+	szAbstractFactory := &Szabstractfactory{
+		Chaos: chaos,
+	}
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	require.NoError(test, err)
+	_, err = szProduct.GetVersion(ctx)
+	require.Error(test, err)
+	szEngine, err := szAbstractFactory.CreateSzEngine(ctx)
+	require.NoError(test, err)
+	_, err = szEngine.GetRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.Error(test, err)
+	report := chaos.Report()
+	assert.Equal(test, 1, report["szproduct.GetVersion"].Calls)
+	assert.Equal(test, 1, report["szengine.GetRecord"].Calls)
+}
+
+func TestSzAbstractFactory_SynchronousNotifications(test *testing.T) {
+	ctx := context.TODO()
+	szAbstractFactory := &Szabstractfactory{
+		SynchronousNotifications: true,
+	}
+	szEngine, err := szAbstractFactory.CreateSzEngine(ctx)
+	require.NoError(test, err)
+	assert.True(test, szEngine.(*szengine.Szengine).SynchronousNotifications)
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	require.NoError(test, err)
+	assert.True(test, szProduct.(*szproduct.Szproduct).SynchronousNotifications)
+}
+
+func TestSzAbstractFactory_Latencies(test *testing.T) {
+	ctx := context.TODO()
+	latencies := helper.NewLatencies(1)
+	latencies.SetDefault(helper.FixedLatency(time.Hour))
+	latencies.Set("szproduct", "GetVersion", helper.FixedLatency(time.Millisecond))
+	szAbstractFactory := &Szabstractfactory{
+		Latencies: latencies,
+	}
+	szProduct, err := szAbstractFactory.CreateSzProduct(ctx)
+	require.NoError(test, err)
+	start := time.Now()
+	_, err = szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	assert.GreaterOrEqual(test, time.Since(start), time.Millisecond)
+	szEngine, err := szAbstractFactory.CreateSzEngine(ctx)
+	require.NoError(test, err)
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = szEngine.GetRecord(ctxWithTimeout, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+}
+
 // ----------------------------------------------------------------------------
 // Internal functions
 // ----------------------------------------------------------------------------
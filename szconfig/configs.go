@@ -0,0 +1,309 @@
+package szconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+)
+
+// Configs holds the in-memory configurations used by Szconfig when its Configs field is set.
+// Each CreateConfig() or ImportConfig() allocates a configuration identified by its own handle.
+// CreateConfig() configurations start from ConfigTemplate.
+// It is safe for concurrent use.
+type Configs struct {
+	configs          map[uintptr]*config
+	mutex            sync.Mutex
+	nextConfigHandle uintptr
+}
+
+type config struct {
+	dataSources []cfgDsrc
+	sections    map[string]json.RawMessage
+}
+
+// An entry of CFG_DSRC.
+type cfgDsrc struct {
+	DsrcID         int64  `json:"DSRC_ID"`
+	DsrcCode       string `json:"DSRC_CODE"`
+	DsrcDesc       string `json:"DSRC_DESC"`
+	DsrcRely       int64  `json:"DSRC_RELY"`
+	RetentionLevel string `json:"RETENTION_LEVEL"`
+	Conversational string `json:"CONVERSATIONAL"`
+}
+
+// An entry of the response of GetDataSources().
+type dataSource struct {
+	DsrcID   int64  `json:"DSRC_ID"`
+	DsrcCode string `json:"DSRC_CODE"`
+}
+
+// The first DSRC_ID assigned to added data sources.
+const firstDsrcID = 1001
+
+/*
+The NewConfigs function creates Configs without configurations.
+
+Output
+  - Configs to be assigned to Szconfig.Configs.
+*/
+func NewConfigs() *Configs {
+	return &Configs{
+		configs:          map[uintptr]*config{},
+		nextConfigHandle: 1,
+	}
+}
+
+/*
+The OpenCount method returns the number of configuration handles not yet closed.
+
+Output
+  - The number of open configuration handles.
+*/
+func (configs *Configs) OpenCount() int {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	return len(configs.configs)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Add a data source checked by canAddDataSource.  Fails if another call added it since.
+func (configs *Configs) addDataSource(configHandle uintptr, code string) (string, error) {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	config, err := configs.getConfig(configHandle)
+	if err != nil {
+		return "", err
+	}
+	added, err := config.newDataSource(code)
+	if err != nil {
+		return "", err
+	}
+	config.dataSources = append(config.dataSources, added)
+	return fmt.Sprintf(`{"DSRC_ID":%d}`, added.DsrcID), nil
+}
+
+// Check that addDataSource would succeed, leaving the configuration unchanged.  Returns the normalized data source code.
+func (configs *Configs) canAddDataSource(configHandle uintptr, dataSourceCode string) (string, error) {
+	code, err := parseDataSourceDefinition(dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	config, err := configs.getConfig(configHandle)
+	if err != nil {
+		return "", err
+	}
+	if _, err := config.newDataSource(code); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// Check that closeConfig would succeed, leaving the configuration open.
+func (configs *Configs) canCloseConfig(configHandle uintptr) error {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	_, err := configs.getConfig(configHandle)
+	return err
+}
+
+// Check that deleteDataSource would succeed, leaving the configuration unchanged.  Returns the normalized data source code.
+func (configs *Configs) canDeleteDataSource(configHandle uintptr, dataSourceCode string) (string, error) {
+	code, err := normalizeDataSourceCode(dataSourceCode)
+	if err != nil {
+		return "", err
+	}
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	config, err := configs.getConfig(configHandle)
+	if err != nil {
+		return "", err
+	}
+	if _, err := config.findDataSource(code); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// Close a configuration checked by canCloseConfig.  Fails if another call closed it since.
+func (configs *Configs) closeConfig(configHandle uintptr) error {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	if _, err := configs.getConfig(configHandle); err != nil {
+		return err
+	}
+	delete(configs.configs, configHandle)
+	return nil
+}
+
+// Delete a data source checked by canDeleteDataSource.  Fails if another call deleted it since.
+func (configs *Configs) deleteDataSource(configHandle uintptr, code string) error {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	config, err := configs.getConfig(configHandle)
+	if err != nil {
+		return err
+	}
+	index, err := config.findDataSource(code)
+	if err != nil {
+		return err
+	}
+	config.dataSources = append(config.dataSources[:index], config.dataSources[index+1:]...)
+	return nil
+}
+
+func (configs *Configs) exportConfig(configHandle uintptr) (string, error) {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	config, err := configs.getConfig(configHandle)
+	if err != nil {
+		return "", err
+	}
+	cfgDsrcs, err := json.Marshal(config.dataSources)
+	if err != nil {
+		return "", err
+	}
+	sections := map[string]json.RawMessage{
+		"CFG_DSRC": cfgDsrcs,
+	}
+	for name, section := range config.sections {
+		sections[name] = section
+	}
+	return marshal(map[string]map[string]json.RawMessage{"G2_CONFIG": sections})
+}
+
+// Get an open configuration.  The caller holds the mutex.
+func (configs *Configs) getConfig(configHandle uintptr) (*config, error) {
+	config, ok := configs.configs[configHandle]
+	if !ok {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("unknown config handle %d", configHandle))
+	}
+	return config, nil
+}
+
+func (configs *Configs) getDataSources(configHandle uintptr) (string, error) {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	config, err := configs.getConfig(configHandle)
+	if err != nil {
+		return "", err
+	}
+	dataSources := []dataSource{}
+	for _, cfgDsrc := range config.dataSources {
+		dataSources = append(dataSources, dataSource{DsrcID: cfgDsrc.DsrcID, DsrcCode: cfgDsrc.DsrcCode})
+	}
+	return marshal(map[string][]dataSource{"DATA_SOURCES": dataSources})
+}
+
+// Allocate a handle for a configuration.
+func (configs *Configs) open(config *config) uintptr {
+	configs.mutex.Lock()
+	defer configs.mutex.Unlock()
+	configHandle := configs.nextConfigHandle
+	configs.nextConfigHandle++
+	configs.configs[configHandle] = config
+	return configHandle
+}
+
+// Find a data source in the configuration.  The caller holds the mutex of its Configs.
+func (config *config) findDataSource(code string) (int, error) {
+	for index, existing := range config.dataSources {
+		if existing.DsrcCode == code {
+			return index, nil
+		}
+	}
+	return 0, helper.NewSzErrorFromCatalog(helper.SzErrorUnknownDataSource, code)
+}
+
+// Build the CFG_DSRC entry of a data source to be added.  The caller holds the mutex of its Configs.
+func (config *config) newDataSource(code string) (cfgDsrc, error) {
+	result := cfgDsrc{
+		DsrcID:         firstDsrcID,
+		DsrcCode:       code,
+		DsrcDesc:       code,
+		DsrcRely:       1,
+		RetentionLevel: "Remember",
+		Conversational: "No",
+	}
+	for _, existing := range config.dataSources {
+		if existing.DsrcCode == code {
+			return cfgDsrc{}, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("data source code [%s] already exists", code))
+		}
+		if existing.DsrcID >= result.DsrcID {
+			result.DsrcID = existing.DsrcID + 1
+		}
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Get the normalized DSRC_CODE of a data source definition (e.g. `{"DSRC_CODE": "CUSTOMERS"}`).
+func parseDataSourceDefinition(dataSourceCode string) (string, error) {
+	request := struct {
+		DsrcCode *string `json:"DSRC_CODE"`
+	}{}
+	if err := json.Unmarshal([]byte(dataSourceCode), &request); err != nil {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid data source definition: %v", err))
+	}
+	if request.DsrcCode == nil {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "missing DSRC_CODE")
+	}
+	return normalizeDataSourceCode(*request.DsrcCode)
+}
+
+// Parse a Senzing configuration, keeping the sections other than CFG_DSRC as they are.
+func parseConfig(configDefinition string) (*config, error) {
+	document := struct {
+		G2Config map[string]json.RawMessage `json:"G2_CONFIG"`
+	}{}
+	if err := json.Unmarshal([]byte(configDefinition), &document); err != nil {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid configuration: %v", err))
+	}
+	if document.G2Config == nil {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "invalid configuration: missing G2_CONFIG")
+	}
+	result := &config{
+		dataSources: []cfgDsrc{},
+		sections:    map[string]json.RawMessage{},
+	}
+	for name, section := range document.G2Config {
+		if name != "CFG_DSRC" {
+			result.sections[name] = section
+			continue
+		}
+		if err := json.Unmarshal(section, &result.dataSources); err != nil {
+			return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid CFG_DSRC: %v", err))
+		}
+	}
+	return result, nil
+}
+
+// Upper-case a data source code, rejecting empty codes and codes containing spaces or control characters.
+func normalizeDataSourceCode(dataSourceCode string) (string, error) {
+	if len(dataSourceCode) == 0 {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "empty DSRC_CODE")
+	}
+	if strings.IndexFunc(dataSourceCode, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid DSRC_CODE %q", dataSourceCode))
+	}
+	return strings.ToUpper(dataSourceCode), nil
+}
+
+func marshal(document interface{}) (string, error) {
+	result, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
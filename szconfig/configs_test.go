@@ -0,0 +1,224 @@
+package szconfig
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestConfigs_AddDataSource(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	actual, err := szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "CUSTOMERS"}`)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DSRC_ID": 1001}`, actual)
+	actual, err = szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "REFERENCE"}`)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DSRC_ID": 1002}`, actual)
+	actual, err = szConfig.GetDataSources(ctx, configHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 2, "DSRC_CODE": "SEARCH"}, {"DSRC_ID": 1001, "DSRC_CODE": "CUSTOMERS"}, {"DSRC_ID": 1002, "DSRC_CODE": "REFERENCE"}]}`, actual)
+	_, err = szConfig.AddDataSource(ctx, configHandle, badConfigDefinition)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestConfigs_AddDataSource_validation(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	actual, err := szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "customers"}`)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DSRC_ID": 1001}`, actual)
+	for _, dataSourceCode := range []string{`{"DSRC_CODE": "CUSTOMERS"}`, `{"DSRC_CODE": "test"}`} {
+		_, err = szConfig.AddDataSource(ctx, configHandle, dataSourceCode)
+		require.ErrorIs(test, err, szerror.ErrSzBadInput, dataSourceCode)
+		assert.Contains(test, err.Error(), "already exists")
+	}
+	for _, dataSourceCode := range []string{"CUSTOMERS", `{}`, `{"DSRC_CODE": ""}`, `{"DSRC_CODE": "\n\tGO_TEST"}`, `{"DSRC_CODE": 1}`} {
+		_, err = szConfig.AddDataSource(ctx, configHandle, dataSourceCode)
+		require.ErrorIs(test, err, szerror.ErrSzBadInput, dataSourceCode)
+	}
+	actual, err = szConfig.GetDataSources(ctx, configHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 2, "DSRC_CODE": "SEARCH"}, {"DSRC_ID": 1001, "DSRC_CODE": "CUSTOMERS"}]}`, actual)
+}
+
+func TestConfigs_CreateConfig_distinctHandles(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	firstHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	secondHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	assert.NotEqual(test, firstHandle, secondHandle)
+	_, err = szConfig.AddDataSource(ctx, firstHandle, `{"DSRC_CODE": "CUSTOMERS"}`)
+	require.NoError(test, err)
+	actual, err := szConfig.GetDataSources(ctx, secondHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 2, "DSRC_CODE": "SEARCH"}]}`, actual)
+	assert.Equal(test, 2, szConfig.Configs.OpenCount())
+}
+
+func TestConfigs_DeleteDataSource(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szConfig.DeleteDataSource(ctx, configHandle, "TEST"))
+	actual, err := szConfig.GetDataSources(ctx, configHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES": [{"DSRC_ID": 2, "DSRC_CODE": "SEARCH"}]}`, actual)
+	require.ErrorIs(test, szConfig.DeleteDataSource(ctx, configHandle, "TEST"), szerror.ErrSzUnknownDataSource)
+	require.ErrorIs(test, szConfig.DeleteDataSource(ctx, configHandle, badDataSourceCode), szerror.ErrSzBadInput)
+	require.NoError(test, szConfig.DeleteDataSource(ctx, configHandle, "search"))
+}
+
+func TestConfigs_ExportConfig_ImportConfig(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	_, err = szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "CUSTOMERS"}`)
+	require.NoError(test, err)
+	configDefinition, err := szConfig.ExportConfig(ctx, configHandle)
+	require.NoError(test, err)
+	importedHandle, err := szConfig.ImportConfig(ctx, configDefinition)
+	require.NoError(test, err)
+	expected, err := szConfig.GetDataSources(ctx, configHandle)
+	require.NoError(test, err)
+	actual, err := szConfig.GetDataSources(ctx, importedHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, expected, actual)
+	_, err = szConfig.ImportConfig(ctx, badConfigDefinition)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestConfigs_ExportConfig_template(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	actual, err := szConfig.ExportConfig(ctx, configHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, ConfigTemplate, actual)
+	_, err = szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "CUSTOMERS"}`)
+	require.NoError(test, err)
+	_, err = szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "REFERENCE"}`)
+	require.NoError(test, err)
+	actual, err = szConfig.ExportConfig(ctx, configHandle)
+	require.NoError(test, err)
+	exported := map[string]map[string]json.RawMessage{}
+	require.NoError(test, json.Unmarshal([]byte(actual), &exported))
+	template := map[string]map[string]json.RawMessage{}
+	require.NoError(test, json.Unmarshal([]byte(ConfigTemplate), &template))
+	for name, section := range template["G2_CONFIG"] {
+		if name != "CFG_DSRC" {
+			assert.JSONEq(test, string(section), string(exported["G2_CONFIG"][name]), name)
+		}
+	}
+	assert.JSONEq(test, `[
+		{"DSRC_ID": 1, "DSRC_CODE": "TEST", "DSRC_DESC": "Test", "DSRC_RELY": 1, "RETENTION_LEVEL": "Remember", "CONVERSATIONAL": "No"},
+		{"DSRC_ID": 2, "DSRC_CODE": "SEARCH", "DSRC_DESC": "Search", "DSRC_RELY": 1, "RETENTION_LEVEL": "Forget", "CONVERSATIONAL": "No"},
+		{"DSRC_ID": 1001, "DSRC_CODE": "CUSTOMERS", "DSRC_DESC": "CUSTOMERS", "DSRC_RELY": 1, "RETENTION_LEVEL": "Remember", "CONVERSATIONAL": "No"},
+		{"DSRC_ID": 1002, "DSRC_CODE": "REFERENCE", "DSRC_DESC": "REFERENCE", "DSRC_RELY": 1, "RETENTION_LEVEL": "Remember", "CONVERSATIONAL": "No"}
+	]`, string(exported["G2_CONFIG"]["CFG_DSRC"]))
+}
+
+func TestConfigs_ImportConfig_malformed(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	for _, configDefinition := range []string{badConfigDefinition, ``, `{}`, `{"G2_CONFIG": []}`, `{"G2_CONFIG": {"CFG_DSRC": {}}}`} {
+		_, err := szConfig.ImportConfig(ctx, configDefinition)
+		require.ErrorIs(test, err, szerror.ErrSzBadInput, configDefinition)
+	}
+	assert.Equal(test, 0, szConfig.Configs.OpenCount())
+	configHandle, err := szConfig.ImportConfig(ctx, ConfigTemplate)
+	require.NoError(test, err)
+	actual, err := szConfig.GetDataSources(ctx, configHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 2, "DSRC_CODE": "SEARCH"}]}`, actual)
+}
+
+func TestConfigs_CloseConfig(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szConfig.CloseConfig(ctx, configHandle))
+	assert.Equal(test, 0, szConfig.Configs.OpenCount())
+	require.ErrorIs(test, szConfig.CloseConfig(ctx, configHandle), szerror.ErrSzBadInput)
+	_, err = szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "CUSTOMERS"}`)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.ErrorIs(test, szConfig.DeleteDataSource(ctx, configHandle, "TEST"), szerror.ErrSzBadInput)
+	_, err = szConfig.ExportConfig(ctx, configHandle)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	_, err = szConfig.GetDataSources(ctx, badConfigHandle)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestConfigs_AddDataSource_laterError(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		AddDataSourceFunc: func(ctx context.Context, configHandle uintptr, dataSourceCode string) (string, error) {
+			return "", helper.NewSzErrorFromCatalog(helper.SzErrorDatabase, "AddDataSourceFunc")
+		},
+		Configs: NewConfigs(),
+		DeleteDataSourceFunc: func(ctx context.Context, configHandle uintptr, dataSourceCode string) error {
+			return helper.NewSzErrorFromCatalog(helper.SzErrorDatabase, "DeleteDataSourceFunc")
+		},
+	}
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	_, err = szConfig.AddDataSource(ctx, configHandle, `{"DSRC_CODE": "CUSTOMERS"}`)
+	require.ErrorIs(test, err, szerror.ErrSzDatabase)
+	require.ErrorIs(test, szConfig.DeleteDataSource(ctx, configHandle, "TEST"), szerror.ErrSzDatabase)
+	actual, err := szConfig.GetDataSources(ctx, configHandle)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCES": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 2, "DSRC_CODE": "SEARCH"}]}`, actual)
+}
+
+func TestConfigs_CreateConfig_laterError(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("CreateConfig", helper.Response{Error: helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost)})
+	responses.Enqueue("CloseConfig", helper.Response{Error: helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost)})
+	szConfig := &Szconfig{
+		Configs:   NewConfigs(),
+		Responses: responses,
+	}
+	_, err := szConfig.CreateConfig(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+	assert.Equal(test, 0, szConfig.Configs.OpenCount())
+	configHandle, err := szConfig.CreateConfig(ctx)
+	require.NoError(test, err)
+	require.ErrorIs(test, szConfig.CloseConfig(ctx, configHandle), szerror.ErrSzDatabaseConnectionLost)
+	assert.Equal(test, 1, szConfig.Configs.OpenCount())
+}
@@ -6,3 +6,6 @@ package szconfig
 
 // Identfier of the szconfig package found messages having the format "senzing-6031xxxx".
 const ComponentID = 6031
+
+// Name of the szconfig package used in reports of helper.Chaos.
+const componentName = "szconfig"
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/senzing-garage/go-logging/logging"
@@ -14,19 +16,51 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szconfig"
 )
 
 type Szconfig struct {
-	AddDataSourceResult  string
-	CreateConfigResult   uintptr
-	isTrace              bool
-	GetDataSourcesResult string
-	ImportConfigResult   uintptr
-	logger               logging.Logging
-	observerOrigin       string
-	observers            subject.Subject
-	ExportConfigResult   string
+	AddDataSourceError       error
+	AddDataSourceFunc        func(ctx context.Context, configHandle uintptr, dataSourceCode string) (string, error)
+	AddDataSourceResult      string
+	calls                    helper.CallRecorder
+	Chaos                    *helper.Chaos
+	CloseConfigError         error
+	CloseConfigFunc          func(ctx context.Context, configHandle uintptr) error
+	Configs                  *Configs
+	CreateConfigError        error
+	CreateConfigFunc         func(ctx context.Context) (uintptr, error)
+	CreateConfigResult       uintptr
+	DeleteDataSourceError    error
+	DeleteDataSourceFunc     func(ctx context.Context, configHandle uintptr, dataSourceCode string) error
+	DestroyError             error
+	DestroyFunc              func(ctx context.Context) error
+	Expectations             *helper.Expectations
+	ExportConfigError        error
+	ExportConfigFunc         func(ctx context.Context, configHandle uintptr) (string, error)
+	ExportConfigResult       string
+	GetDataSourcesError      error
+	GetDataSourcesFunc       func(ctx context.Context, configHandle uintptr) (string, error)
+	GetDataSourcesResult     string
+	HonorContext             bool
+	ImportConfigError        error
+	ImportConfigFunc         func(ctx context.Context, configDefinition string) (uintptr, error)
+	ImportConfigResult       uintptr
+	InitializeError          error
+	InitializeFunc           func(ctx context.Context, instanceName string, settings string, verboseLogging int64) error
+	isTrace                  atomic.Bool
+	Latencies                *helper.Latencies
+	lifecycle                helper.Lifecycle
+	logger                   logging.Logging
+	mutex                    sync.RWMutex
+	notifications            helper.Notifications
+	observerOrigin           string
+	observers                subject.Subject
+	Responses                *helper.ResponseQueues
+	StrictLifecycle          bool
+	SynchronousNotifications bool
+	Triggers                 *helper.Triggers
 }
 
 const (
@@ -56,22 +90,47 @@ Output
 func (client *Szconfig) AddDataSource(ctx context.Context, configHandle uintptr, dataSourceCode string) (string, error) {
 	var err error
 	result := client.AddDataSourceResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(1, configHandle, dataSourceCode)
 		defer func() {
 			client.traceExit(2, configHandle, dataSourceCode, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "AddDataSource", err)
+	if err == nil {
+		err = client.AddDataSourceError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "AddDataSource", err)
+		err = helper.TriggeredError(client.Triggers, "AddDataSource", err)
+		code := ""
+		if err == nil && client.Configs != nil {
+			code, err = client.Configs.canAddDataSource(configHandle, dataSourceCode)
+		}
+		result, err = helper.QueuedResult(client.Responses, "AddDataSource", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "AddDataSource", result, err, configHandle, dataSourceCode)
+		if client.AddDataSourceFunc != nil {
+			result, err = client.AddDataSourceFunc(ctx, configHandle, dataSourceCode)
+		}
+		if err == nil && len(code) > 0 {
+			var added string
+			added, err = client.Configs.addDataSource(configHandle, code)
+			if err == nil && client.AddDataSourceFunc == nil && result == client.AddDataSourceResult {
+				result = added
+			}
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"return":         result,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8001, err, details)
+		})
 	}
+	client.record("AddDataSource", senzing.SzNoFlags, result, err, configHandle, dataSourceCode)
 	return result, err
 }
 
@@ -85,17 +144,39 @@ Input
 */
 func (client *Szconfig) CloseConfig(ctx context.Context, configHandle uintptr) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(5, configHandle)
 		defer func() { client.traceExit(6, configHandle, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "CloseConfig", err)
+	if err == nil {
+		err = client.CloseConfigError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "CloseConfig", err)
+		err = helper.TriggeredError(client.Triggers, "CloseConfig", err)
+		isValid := false
+		if err == nil && client.Configs != nil {
+			err = client.Configs.canCloseConfig(configHandle)
+			isValid = err == nil
+		}
+		err = helper.QueuedError(client.Responses, "CloseConfig", err)
+		err = helper.ExpectedError(client.Expectations, "CloseConfig", err, configHandle)
+		if client.CloseConfigFunc != nil {
+			err = client.CloseConfigFunc(ctx, configHandle)
+		}
+		if err == nil && isValid {
+			err = client.Configs.closeConfig(configHandle)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8002, err, details)
+		})
 	}
+	client.record("CloseConfig", senzing.SzNoFlags, nil, err, configHandle)
 	return err
 }
 
@@ -115,17 +196,41 @@ Output
 func (client *Szconfig) CreateConfig(ctx context.Context) (uintptr, error) {
 	var err error
 	result := client.CreateConfigResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(7)
 		defer func() { client.traceExit(8, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "CreateConfig", err)
+	if err == nil {
+		err = client.CreateConfigError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "CreateConfig", err)
+		err = helper.TriggeredError(client.Triggers, "CreateConfig", err)
+		var parsed *config
+		if err == nil && client.Configs != nil {
+			parsed, err = parseConfig(ConfigTemplate)
+		}
+		result, err = helper.QueuedResult(client.Responses, "CreateConfig", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "CreateConfig", result, err)
+		if client.CreateConfigFunc != nil {
+			result, err = client.CreateConfigFunc(ctx)
+		}
+		if err == nil && parsed != nil {
+			configHandle := client.Configs.open(parsed)
+			if client.CreateConfigFunc == nil && result == client.CreateConfigResult {
+				result = configHandle
+			}
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8003, err, details)
+		})
 	}
+	client.record("CreateConfig", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -140,19 +245,40 @@ Input
 */
 func (client *Szconfig) DeleteDataSource(ctx context.Context, configHandle uintptr, dataSourceCode string) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(9, configHandle, dataSourceCode)
 		defer func() { client.traceExit(10, configHandle, dataSourceCode, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "DeleteDataSource", err)
+	if err == nil {
+		err = client.DeleteDataSourceError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "DeleteDataSource", err)
+		err = helper.TriggeredError(client.Triggers, "DeleteDataSource", err)
+		code := ""
+		if err == nil && client.Configs != nil {
+			code, err = client.Configs.canDeleteDataSource(configHandle, dataSourceCode)
+		}
+		err = helper.QueuedError(client.Responses, "DeleteDataSource", err)
+		err = helper.ExpectedError(client.Expectations, "DeleteDataSource", err, configHandle, dataSourceCode)
+		if client.DeleteDataSourceFunc != nil {
+			err = client.DeleteDataSourceFunc(ctx, configHandle, dataSourceCode)
+		}
+		if err == nil && len(code) > 0 {
+			err = client.Configs.deleteDataSource(configHandle, code)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8004, err, details)
+		})
 	}
+	client.record("DeleteDataSource", senzing.SzNoFlags, nil, err, configHandle, dataSourceCode)
 	return err
 }
 
@@ -165,17 +291,31 @@ Input
 */
 func (client *Szconfig) Destroy(ctx context.Context) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(11)
 		defer func() { client.traceExit(12, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Destroy", err)
+	if err == nil {
+		err = client.DestroyError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Destroy", err)
+		err = helper.TriggeredError(client.Triggers, "Destroy", err)
+		err = helper.QueuedError(client.Responses, "Destroy", err)
+		err = helper.ExpectedError(client.Expectations, "Destroy", err)
+		if client.DestroyFunc != nil {
+			err = client.DestroyFunc(ctx)
+		}
+		err = client.lifecycle.Destroy(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8005, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8005, err, details)
+		})
 	}
+	client.record("Destroy", senzing.SzNoFlags, nil, err)
 	return err
 }
 
@@ -194,17 +334,34 @@ Output
 func (client *Szconfig) ExportConfig(ctx context.Context, configHandle uintptr) (string, error) {
 	var err error
 	result := client.ExportConfigResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(13, configHandle)
 		defer func() { client.traceExit(14, configHandle, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ExportConfig", err)
+	if err == nil {
+		err = client.ExportConfigError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ExportConfig", err)
+		err = helper.TriggeredError(client.Triggers, "ExportConfig", err)
+		if err == nil && client.Configs != nil {
+			result, err = client.Configs.exportConfig(configHandle)
+		}
+		result, err = helper.QueuedResult(client.Responses, "ExportConfig", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ExportConfig", result, err, configHandle)
+		if client.ExportConfigFunc != nil {
+			result, err = client.ExportConfigFunc(ctx, configHandle)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8006, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8006, err, details)
+		})
 	}
+	client.record("ExportConfig", senzing.SzNoFlags, result, err, configHandle)
 	return result, err
 }
 
@@ -223,17 +380,34 @@ Output
 func (client *Szconfig) GetDataSources(ctx context.Context, configHandle uintptr) (string, error) {
 	var err error
 	result := client.GetDataSourcesResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(15, configHandle)
 		defer func() { client.traceExit(16, configHandle, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetDataSources", err)
+	if err == nil {
+		err = client.GetDataSourcesError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetDataSources", err)
+		err = helper.TriggeredError(client.Triggers, "GetDataSources", err)
+		if err == nil && client.Configs != nil {
+			result, err = client.Configs.getDataSources(configHandle)
+		}
+		result, err = helper.QueuedResult(client.Responses, "GetDataSources", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetDataSources", result, err, configHandle)
+		if client.GetDataSourcesFunc != nil {
+			result, err = client.GetDataSourcesFunc(ctx, configHandle)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8008, err, details)
+		})
 	}
+	client.record("GetDataSources", senzing.SzNoFlags, result, err, configHandle)
 	return result, err
 }
 
@@ -250,17 +424,41 @@ Output
 func (client *Szconfig) ImportConfig(ctx context.Context, configDefinition string) (uintptr, error) {
 	var err error
 	result := client.ImportConfigResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(21, configDefinition)
 		defer func() { client.traceExit(22, configDefinition, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ImportConfig", err)
+	if err == nil {
+		err = client.ImportConfigError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ImportConfig", err)
+		err = helper.TriggeredError(client.Triggers, "ImportConfig", err)
+		var parsed *config
+		if err == nil && client.Configs != nil {
+			parsed, err = parseConfig(configDefinition)
+		}
+		result, err = helper.QueuedResult(client.Responses, "ImportConfig", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ImportConfig", result, err, configDefinition)
+		if client.ImportConfigFunc != nil {
+			result, err = client.ImportConfigFunc(ctx, configDefinition)
+		}
+		if err == nil && parsed != nil {
+			configHandle := client.Configs.open(parsed)
+			if client.ImportConfigFunc == nil && result == client.ImportConfigResult {
+				result = configHandle
+			}
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8009, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8009, err, details)
+		})
 	}
+	client.record("ImportConfig", senzing.SzNoFlags, result, err, configDefinition)
 	return result, err
 }
 
@@ -268,6 +466,34 @@ func (client *Szconfig) ImportConfig(ctx context.Context, configDefinition strin
 // Public non-interface methods
 // ----------------------------------------------------------------------------
 
+/*
+The FlushNotifications method waits until observers have received the notifications
+sent asynchronously by the Szconfig object.
+Unnecessary if SynchronousNotifications is set.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - ctx.Err() if ctx was cancelled or expired before the notifications were delivered.
+*/
+func (client *Szconfig) FlushNotifications(ctx context.Context) error {
+	return client.notifications.Wait(ctx)
+}
+
+/*
+The GetCallRecorder method returns the log of calls made to the Szconfig object.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The CallRecorder holding the calls made to the Szconfig object.
+*/
+func (client *Szconfig) GetCallRecorder(ctx context.Context) *helper.CallRecorder {
+	return &client.calls
+}
+
 /*
 The GetObserverOrigin method returns the "origin" value of past Observer messages.
 
@@ -279,6 +505,8 @@ Output
 */
 func (client *Szconfig) GetObserverOrigin(ctx context.Context) string {
 	_ = ctx
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
 	return client.observerOrigin
 }
 
@@ -294,21 +522,35 @@ Input
 */
 func (client *Szconfig) Initialize(ctx context.Context, instanceName string, settings string, verboseLogging int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(23, instanceName, settings, verboseLogging)
 		defer func() { client.traceExit(24, instanceName, settings, verboseLogging, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Initialize", err)
+	if err == nil {
+		err = client.InitializeError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Initialize", err)
+		err = helper.TriggeredError(client.Triggers, "Initialize", err)
+		err = helper.QueuedError(client.Responses, "Initialize", err)
+		err = helper.ExpectedError(client.Expectations, "Initialize", err, instanceName, settings, verboseLogging)
+		if client.InitializeFunc != nil {
+			err = client.InitializeFunc(ctx, instanceName, settings, verboseLogging)
+		}
+		err = client.lifecycle.Initialize(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"instanceName":   instanceName,
 				"settings":       settings,
 				"verboseLogging": strconv.FormatInt(verboseLogging, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8007, err, details)
+		})
 	}
+	client.record("Initialize", senzing.SzNoFlags, nil, err, instanceName, settings, verboseLogging)
 	return err
 }
 
@@ -321,22 +563,24 @@ Input
 */
 func (client *Szconfig) RegisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(703, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
+	client.mutex.Lock()
 	if client.observers == nil {
-		client.observers = &subject.SimpleSubject{}
+		client.observers = &helper.SynchronousSubject{}
 	}
 	err = client.observers.RegisterObserver(ctx, observer)
-	if client.observers != nil {
-		go func() {
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8702, err, details)
+		})
 	}
 	return err
 }
@@ -350,7 +594,7 @@ Input
 */
 func (client *Szconfig) SetLogLevel(ctx context.Context, logLevelName string) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(705, logLevelName)
 		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
@@ -358,15 +602,17 @@ func (client *Szconfig) SetLogLevel(ctx context.Context, logLevelName string) er
 	if !logging.IsValidLogLevelName(logLevelName) {
 		return fmt.Errorf("invalid error level: %s", logLevelName)
 	}
+	client.mutex.Lock()
 	err = client.getLogger().SetLogLevel(logLevelName)
-	client.isTrace = (logLevelName == logging.LevelTraceName)
-	if client.observers != nil {
-		go func() {
+	client.isTrace.Store(logLevelName == logging.LevelTraceName)
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8703, err, details)
+		})
 	}
 	return err
 }
@@ -380,6 +626,8 @@ Input
 */
 func (client *Szconfig) SetObserverOrigin(ctx context.Context, origin string) {
 	_ = ctx
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.observerOrigin = origin
 }
 
@@ -392,23 +640,26 @@ Input
 */
 func (client *Szconfig) UnregisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(707, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
+	if observers, observerOrigin := client.getObservers(); observers != nil {
 		// Tricky code:
-		// client.notify is called synchronously before client.observers is set to nil.
-		// In client.notify, each observer will get notified in a goroutine.
-		// Then client.observers may be set to nil, but observer goroutines will be OK.
+		// The observer being removed is notified, before it is removed, in the calling goroutine.
+		// client.mutex is not held, so observers may call the Szconfig object.
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
-		err = client.observers.UnregisterObserver(ctx, observer)
-		if !client.observers.HasObservers(ctx) {
-			client.observers = nil
+		notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8704, err, details)
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		if client.observers != nil {
+			err = client.observers.UnregisterObserver(ctx, observer)
+			if !client.observers.HasObservers(ctx) {
+				client.observers = nil
+			}
 		}
 	}
 	return err
@@ -420,7 +671,7 @@ func (client *Szconfig) UnregisterObserver(ctx context.Context, observer observe
 
 // --- Logging ----------------------------------------------------------------
 
-// Get the Logger singleton.
+// Get the Logger singleton.  The caller holds client.mutex.
 func (client *Szconfig) getLogger() logging.Logging {
 	if client.logger == nil {
 		client.logger = helper.GetLogger(ComponentID, szconfig.IDMessages, baseCallerSkip)
@@ -428,12 +679,38 @@ func (client *Szconfig) getLogger() logging.Logging {
 	return client.logger
 }
 
+// Get the observers and the origin of their messages.
+func (client *Szconfig) getObservers() (subject.Subject, string) {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.observers, client.observerOrigin
+}
+
 // Trace method entry.
 func (client *Szconfig) traceEntry(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
 
 // Trace method exit.
 func (client *Szconfig) traceExit(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
+
+// ----------------------------------------------------------------------------
+// Call recording
+// ----------------------------------------------------------------------------
+
+// Record the call in the call log.
+func (client *Szconfig) record(method string, flags int64, result interface{}, err error, arguments ...interface{}) {
+	client.calls.Record(helper.Call{
+		Arguments: arguments,
+		Error:     err,
+		Flags:     flags,
+		Method:    method,
+		Result:    result,
+	})
+}
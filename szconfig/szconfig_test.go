@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/helper/helpertest"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -52,6 +55,126 @@ func TestSzconfig_AddDataSource(test *testing.T) {
 	require.NoError(test, err)
 }
 
+func TestSzconfig_AddDataSource_error(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		AddDataSourceError: helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "{}"),
+	}
+	_, err := szConfig.AddDataSource(ctx, 0, "{}")
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestSzconfig_AddDataSource_latencies(test *testing.T) {
+	ctx := context.TODO()
+	latencies := helper.NewLatencies(1)
+	latencies.Set(componentName, "AddDataSource", helper.FixedLatency(time.Hour))
+	szConfig := &Szconfig{
+		Latencies: latencies,
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := szConfig.AddDataSource(ctxWithTimeout, 0, "{}")
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	latencies.Set(componentName, "AddDataSource", helper.FixedLatency(time.Millisecond))
+	start := time.Now()
+	_, err = szConfig.AddDataSource(ctx, 0, "{}")
+	require.NoError(test, err)
+	assert.GreaterOrEqual(test, time.Since(start), time.Millisecond)
+}
+
+func TestSzconfig_AddDataSource_chaos(test *testing.T) {
+	ctx := context.TODO()
+	chaos := helper.NewChaos(1, helper.ChaosSettings{})
+	chaos.Set(componentName, "AddDataSource", helper.ChaosSettings{FailureRate: 1})
+	szConfig := &Szconfig{
+		Chaos: chaos,
+	}
+	_, err := szConfig.AddDataSource(ctx, 0, "{}")
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	report := chaos.Report()
+	assert.Equal(test, map[string]int{helper.SzErrorDatabaseConnectionLost: 1}, report[componentName+".AddDataSource"].Faults)
+	chaos.Set(componentName, "AddDataSource", helper.ChaosSettings{})
+	_, err = szConfig.AddDataSource(ctx, 0, "{}")
+	require.NoError(test, err)
+}
+
+func TestSzconfig_AddDataSource_triggers(test *testing.T) {
+	ctx := context.TODO()
+	triggers := helper.NewTriggers()
+	triggers.Add("AddDataSource", helper.OnCall(2), helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost))
+	szConfig := &Szconfig{
+		Triggers: triggers,
+	}
+	for call := 1; call <= 3; call++ {
+		_, err := szConfig.AddDataSource(ctx, 0, "{}")
+		if call == 2 {
+			require.ErrorIs(test, err, szerror.ErrSzRetryable)
+		} else {
+			require.NoError(test, err)
+		}
+	}
+	assert.Equal(test, 3, triggers.Calls("AddDataSource"))
+}
+
+func TestSzconfig_AddDataSource_honorContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	szConfig := &Szconfig{
+		HonorContext: true,
+	}
+	_, err := szConfig.AddDataSource(ctx, 1, `{"DSRC_CODE":"CUSTOMERS"}`)
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestSzconfig_AddDataSource_strictLifecycle(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		StrictLifecycle: true,
+	}
+	_, err := szConfig.AddDataSource(ctx, 1, `{"DSRC_CODE":"CUSTOMERS"}`)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+	require.NoError(test, szConfig.Initialize(ctx, "mock", "{}", senzing.SzNoLogging))
+	require.ErrorIs(test, szConfig.Initialize(ctx, "mock", "{}", senzing.SzNoLogging), szerror.ErrSzBadInput)
+	_, err = szConfig.AddDataSource(ctx, 1, `{"DSRC_CODE":"CUSTOMERS"}`)
+	require.NoError(test, err)
+	require.NoError(test, szConfig.Destroy(ctx))
+	require.ErrorIs(test, szConfig.Destroy(ctx), szerror.ErrSzNotInitialized)
+	_, err = szConfig.AddDataSource(ctx, 1, `{"DSRC_CODE":"CUSTOMERS"}`)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+}
+
+func TestSzconfig_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{
+		Configs: NewConfigs(),
+	}
+	helpertest.RunConcurrently(ctx, test, szConfig, 8, 50, func(worker int, iteration int) error {
+		configHandle, err := szConfig.CreateConfig(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = szConfig.AddDataSource(ctx, configHandle, fmt.Sprintf(`{"DSRC_CODE": "WORKER_%d_%d"}`, worker, iteration))
+		if err != nil {
+			return err
+		}
+		return szConfig.CloseConfig(ctx, configHandle)
+	})
+	assert.Equal(test, 8*50, szConfig.GetCallRecorder(ctx).Count("CreateConfig"))
+	assert.Equal(test, 0, szConfig.Configs.OpenCount())
+}
+
+func TestSzconfig_AddDataSource_expectations(test *testing.T) {
+	ctx := context.TODO()
+	expectations := helper.NewExpectations(test)
+	expectations.Expect("AddDataSource", helper.Any(), helper.JSONEq(`{"DSRC_CODE": "CUSTOMERS"}`)).Return(`{"DSRC_ID": 1001}`, nil)
+	szConfig := &Szconfig{
+		Expectations: expectations,
+	}
+	actual, err := szConfig.AddDataSource(ctx, 1, `{"DSRC_CODE":"CUSTOMERS"}`)
+	require.NoError(test, err)
+	assert.Equal(test, `{"DSRC_ID": 1001}`, actual)
+}
+
 func TestSzconfig_AddDataSource_withLoad(test *testing.T) {
 	ctx := context.TODO()
 	szConfig := getTestObject(ctx, test)
@@ -101,6 +224,23 @@ func TestSzconfig_CreateConfig(test *testing.T) {
 // TODO: Implement TestSzconfig_CreateConfig_error
 // func TestSzconfig_CreateConfig_error(test *testing.T) {}
 
+func TestSzconfig_CreateConfig_func(test *testing.T) {
+	ctx := context.TODO()
+	nextConfigHandle := uintptr(0)
+	szConfig := &Szconfig{
+		CreateConfigFunc: func(ctx context.Context) (uintptr, error) {
+			_ = ctx
+			nextConfigHandle++
+			return nextConfigHandle, nil
+		},
+	}
+	for _, expected := range []uintptr{1, 2} {
+		actual, err := szConfig.CreateConfig(ctx)
+		require.NoError(test, err)
+		assert.Equal(test, expected, actual)
+	}
+}
+
 func TestSzconfig_DeleteDataSource(test *testing.T) {
 	ctx := context.TODO()
 	szConfig := getTestObject(ctx, test)
@@ -205,6 +345,16 @@ func TestSzconfig_SetObserverOrigin(test *testing.T) {
 	szConfig.SetObserverOrigin(ctx, origin)
 }
 
+func TestSzconfig_GetCallRecorder(test *testing.T) {
+	ctx := context.TODO()
+	szConfig := &Szconfig{}
+	_, err := szConfig.AddDataSource(ctx, 1, `{"DSRC_CODE": "CUSTOMERS"}`)
+	require.NoError(test, err)
+	lastCall, ok := szConfig.GetCallRecorder(ctx).LastCall("AddDataSource")
+	require.True(test, ok)
+	assert.Equal(test, []interface{}{uintptr(1), `{"DSRC_CODE": "CUSTOMERS"}`}, lastCall.Arguments)
+}
+
 func TestSzconfig_GetObserverOrigin(test *testing.T) {
 	ctx := context.TODO()
 	szConfig := getTestObject(ctx, test)
@@ -301,7 +451,7 @@ func getSzConfig(ctx context.Context) (*Szconfig, error) {
 		szConfigSingleton = &Szconfig{
 			AddDataSourceResult:  `{"DSRC_ID":1001}`,
 			CreateConfigResult:   1,
-			ExportConfigResult:   `{"G2_CONFIG":{"CFG_ATTR":[{"ATTR_ID":1001,"ATTR_CODE":"DATA_SOURCE","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1003,"ATTR_CODE":"RECORD_ID","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1007,"ATTR_CODE":"DSRC_ACTION","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1101,"ATTR_CODE":"NAME_TYPE","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"USAGE_TYPE","FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1102,"ATTR_CODE":"NAME_FULL","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"FULL_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1103,"ATTR_CODE":"NAME_ORG","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"ORG_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1104,"ATTR_CODE":"NAME_LAST","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"SUR_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1105,"ATTR_CODE":"NAME_FIRST","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"GIVEN_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1106,"ATTR_CODE":"NAME_MIDDLE",`,
+			ExportConfigResult:   ConfigTemplate,
 			GetDataSourcesResult: `{"DATA_SOURCES":[{"DSRC_ID":1,"DSRC_CODE":"TEST"},{"DSRC_ID":2,"DSRC_CODE":"SEARCH"}]}`,
 			ImportConfigResult:   1,
 		}
@@ -0,0 +1,8 @@
+package szconfig
+
+/*
+ConfigTemplate is the minimal, valid Senzing configuration that CreateConfig() starts from when
+Szconfig.Configs is set.  Its CFG_DSRC registers the TEST and SEARCH data sources.
+It may also be used as the static result of methods returning a configuration (e.g. Szconfig.ExportConfigResult).
+*/
+const ConfigTemplate = `{"G2_CONFIG":{"CFG_ATTR":[{"ATTR_ID":1001,"ATTR_CODE":"DATA_SOURCE","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1003,"ATTR_CODE":"RECORD_ID","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1007,"ATTR_CODE":"DSRC_ACTION","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1101,"ATTR_CODE":"NAME_TYPE","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"USAGE_TYPE","FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1102,"ATTR_CODE":"NAME_FULL","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"FULL_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1103,"ATTR_CODE":"NAME_ORG","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"ORG_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1104,"ATTR_CODE":"NAME_LAST","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"SUR_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1105,"ATTR_CODE":"NAME_FIRST","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"GIVEN_NAME","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1106,"ATTR_CODE":"NAME_MIDDLE","ATTR_CLASS":"NAME","FTYPE_CODE":"NAME","FELEM_CODE":"MIDDLE_NAME","FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1201,"ATTR_CODE":"DATE_OF_BIRTH","ATTR_CLASS":"ATTRIBUTE","FTYPE_CODE":"DOB","FELEM_CODE":"DATE","FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1301,"ATTR_CODE":"ADDR_TYPE","ATTR_CLASS":"ADDRESS","FTYPE_CODE":"ADDRESS","FELEM_CODE":"USAGE_TYPE","FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1302,"ATTR_CODE":"ADDR_FULL","ATTR_CLASS":"ADDRESS","FTYPE_CODE":"ADDRESS","FELEM_CODE":"ADDR_FULL","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1311,"ATTR_CODE":"ADDR_CITY","ATTR_CLASS":"ADDRESS","FTYPE_CODE":"ADDRESS","FELEM_CODE":"CITY","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1313,"ATTR_CODE":"ADDR_POSTAL_CODE","ATTR_CLASS":"ADDRESS","FTYPE_CODE":"ADDRESS","FELEM_CODE":"POSTAL_CODE","FELEM_REQ":"Any","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1401,"ATTR_CODE":"PHONE_TYPE","ATTR_CLASS":"PHONE","FTYPE_CODE":"PHONE","FELEM_CODE":"USAGE_TYPE","FELEM_REQ":"No","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1402,"ATTR_CODE":"PHONE_NUMBER","ATTR_CLASS":"PHONE","FTYPE_CODE":"PHONE","FELEM_CODE":"PHONE_NUM","FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1601,"ATTR_CODE":"SSN_NUMBER","ATTR_CLASS":"IDENTIFIER","FTYPE_CODE":"SSN","FELEM_CODE":"ID_NUM","FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"},{"ATTR_ID":1801,"ATTR_CODE":"EMAIL_ADDRESS","ATTR_CLASS":"IDENTIFIER","FTYPE_CODE":"EMAIL","FELEM_CODE":"ADDR","FELEM_REQ":"Yes","DEFAULT_VALUE":null,"INTERNAL":"No"}],"CFG_DSRC":[{"DSRC_ID":1,"DSRC_CODE":"TEST","DSRC_DESC":"Test","DSRC_RELY":1,"RETENTION_LEVEL":"Remember","CONVERSATIONAL":"No"},{"DSRC_ID":2,"DSRC_CODE":"SEARCH","DSRC_DESC":"Search","DSRC_RELY":1,"RETENTION_LEVEL":"Forget","CONVERSATIONAL":"No"}],"CFG_FELEM":[{"FELEM_ID":1,"FELEM_CODE":"USAGE_TYPE","FELEM_DESC":"Usage Type","DATA_TYPE":"string"},{"FELEM_ID":2,"FELEM_CODE":"FULL_NAME","FELEM_DESC":"Full Name","DATA_TYPE":"string"},{"FELEM_ID":3,"FELEM_CODE":"ORG_NAME","FELEM_DESC":"Org Name","DATA_TYPE":"string"},{"FELEM_ID":4,"FELEM_CODE":"SUR_NAME","FELEM_DESC":"Sur Name","DATA_TYPE":"string"},{"FELEM_ID":5,"FELEM_CODE":"GIVEN_NAME","FELEM_DESC":"Given Name","DATA_TYPE":"string"},{"FELEM_ID":6,"FELEM_CODE":"MIDDLE_NAME","FELEM_DESC":"Middle Name","DATA_TYPE":"string"},{"FELEM_ID":7,"FELEM_CODE":"DATE","FELEM_DESC":"Date","DATA_TYPE":"string"},{"FELEM_ID":8,"FELEM_CODE":"ADDR_FULL","FELEM_DESC":"Addr Full","DATA_TYPE":"string"},{"FELEM_ID":9,"FELEM_CODE":"CITY","FELEM_DESC":"City","DATA_TYPE":"string"},{"FELEM_ID":10,"FELEM_CODE":"POSTAL_CODE","FELEM_DESC":"Postal Code","DATA_TYPE":"string"},{"FELEM_ID":11,"FELEM_CODE":"PHONE_NUM","FELEM_DESC":"Phone Num","DATA_TYPE":"string"},{"FELEM_ID":12,"FELEM_CODE":"ID_NUM","FELEM_DESC":"Id Num","DATA_TYPE":"string"},{"FELEM_ID":13,"FELEM_CODE":"ADDR","FELEM_DESC":"Addr","DATA_TYPE":"string"}],"CFG_FTYPE":[{"FTYPE_ID":1,"FTYPE_CODE":"NAME","FTYPE_DESC":"Name","FCLASS_CODE":"NAME","FTYPE_FREQ":"FM","FTYPE_EXCL":"No","FTYPE_STAB":"No","ANONYMIZE":"No","DERIVED":"No","USED_FOR_CAND":"Yes","PERSIST_HISTORY":"Yes","VERSION":1},{"FTYPE_ID":2,"FTYPE_CODE":"DOB","FTYPE_DESC":"Date of birth","FCLASS_CODE":"ATTRIBUTE","FTYPE_FREQ":"F1","FTYPE_EXCL":"No","FTYPE_STAB":"No","ANONYMIZE":"No","DERIVED":"No","USED_FOR_CAND":"Yes","PERSIST_HISTORY":"Yes","VERSION":1},{"FTYPE_ID":5,"FTYPE_CODE":"ADDRESS","FTYPE_DESC":"Address","FCLASS_CODE":"ADDRESS","FTYPE_FREQ":"FM","FTYPE_EXCL":"No","FTYPE_STAB":"No","ANONYMIZE":"No","DERIVED":"No","USED_FOR_CAND":"Yes","PERSIST_HISTORY":"Yes","VERSION":1},{"FTYPE_ID":6,"FTYPE_CODE":"PHONE","FTYPE_DESC":"Phone","FCLASS_CODE":"PHONE","FTYPE_FREQ":"FM","FTYPE_EXCL":"No","FTYPE_STAB":"No","ANONYMIZE":"No","DERIVED":"No","USED_FOR_CAND":"Yes","PERSIST_HISTORY":"Yes","VERSION":1},{"FTYPE_ID":7,"FTYPE_CODE":"SSN","FTYPE_DESC":"Social Security Number","FCLASS_CODE":"IDENTIFIER","FTYPE_FREQ":"F1","FTYPE_EXCL":"No","FTYPE_STAB":"No","ANONYMIZE":"No","DERIVED":"No","USED_FOR_CAND":"Yes","PERSIST_HISTORY":"Yes","VERSION":1},{"FTYPE_ID":27,"FTYPE_CODE":"EMAIL","FTYPE_DESC":"Email","FCLASS_CODE":"IDENTIFIER","FTYPE_FREQ":"FM","FTYPE_EXCL":"No","FTYPE_STAB":"No","ANONYMIZE":"No","DERIVED":"No","USED_FOR_CAND":"Yes","PERSIST_HISTORY":"Yes","VERSION":1}],"CONFIG_BASE_VERSION":{"VERSION":"4.0.0","BUILD_VERSION":"4.0.0.00000","BUILD_DATE":"2024-01-01","BUILD_NUMBER":"00000","COMPATIBILITY_VERSION":{"CONFIG_VERSION":"11"}},"SYS_OOM":[]}}`
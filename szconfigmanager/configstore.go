@@ -0,0 +1,172 @@
+package szconfigmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+)
+
+// ConfigStore holds the configurations used by Szconfigmanager when its ConfigStore field is set,
+// in place of the Senzing database.
+// AddConfig() stores a configuration under a new configuration identifier.
+// Only stored configurations can become the default, and ReplaceDefaultConfigID() changes the default atomically.
+// It is safe for concurrent use.
+type ConfigStore struct {
+	configs         []storedConfig
+	defaultConfigID int64
+	mutex           sync.Mutex
+	nextConfigID    int64
+	now             func() time.Time
+}
+
+type storedConfig struct {
+	configComment    string
+	configDefinition string
+	configID         int64
+	sysCreateDt      time.Time
+}
+
+// An entry of the response of GetConfigs().
+type configEntry struct {
+	ConfigID       int64  `json:"CONFIG_ID"`
+	ConfigComments string `json:"CONFIG_COMMENTS"`
+	SysCreateDt    string `json:"SYS_CREATE_DT"`
+}
+
+// Format of SYS_CREATE_DT.
+const sysCreateDtLayout = "2006-01-02 15:04:05.000"
+
+/*
+The NewConfigStore function creates a ConfigStore without configurations and without a default configuration.
+
+Output
+  - ConfigStore to be assigned to Szconfigmanager.ConfigStore.
+*/
+func NewConfigStore() *ConfigStore {
+	return &ConfigStore{
+		configs:      []storedConfig{},
+		nextConfigID: 1,
+		now:          time.Now,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Store a configuration validated by checkConfigDefinition.
+func (configStore *ConfigStore) addConfig(configDefinition string, configComment string) int64 {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	configID := configStore.nextConfigID
+	configStore.nextConfigID++
+	configStore.configs = append(configStore.configs, storedConfig{
+		configComment:    configComment,
+		configDefinition: configDefinition,
+		configID:         configID,
+		sysCreateDt:      configStore.now().UTC(),
+	})
+	return configID
+}
+
+// Check that replaceDefaultConfigID would succeed, leaving the store unchanged.
+func (configStore *ConfigStore) canReplaceDefaultConfigID(currentDefaultConfigID int64, newDefaultConfigID int64) error {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	return configStore.checkReplacement(currentDefaultConfigID, newDefaultConfigID)
+}
+
+// Check a replacement of the default configuration identifier.  The caller holds the mutex.
+func (configStore *ConfigStore) checkReplacement(currentDefaultConfigID int64, newDefaultConfigID int64) error {
+	if _, err := configStore.findConfig(newDefaultConfigID); err != nil {
+		return err
+	}
+	if configStore.defaultConfigID != currentDefaultConfigID {
+		return helper.NewSzErrorFromCatalog(helper.SzErrorReplaceConflict, fmt.Sprintf("expected %d, found %d", currentDefaultConfigID, configStore.defaultConfigID))
+	}
+	return nil
+}
+
+// Find a stored configuration.  The caller holds the mutex.
+func (configStore *ConfigStore) findConfig(configID int64) (*storedConfig, error) {
+	for index := range configStore.configs {
+		if configStore.configs[index].configID == configID {
+			return &configStore.configs[index], nil
+		}
+	}
+	return nil, helper.NewSzErrorFromCatalog(helper.SzErrorConfigNotFound, configID)
+}
+
+func (configStore *ConfigStore) getConfig(configID int64) (string, error) {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	config, err := configStore.findConfig(configID)
+	if err != nil {
+		return "", err
+	}
+	return config.configDefinition, nil
+}
+
+func (configStore *ConfigStore) getConfigs() (string, error) {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	configs := []configEntry{}
+	for _, config := range configStore.configs {
+		configs = append(configs, configEntry{
+			ConfigID:       config.configID,
+			ConfigComments: config.configComment,
+			SysCreateDt:    config.sysCreateDt.Format(sysCreateDtLayout),
+		})
+	}
+	result, err := json.Marshal(map[string][]configEntry{"CONFIGS": configs})
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+func (configStore *ConfigStore) getDefaultConfigID() int64 {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	return configStore.defaultConfigID
+}
+
+// Check that a configuration is stored, leaving the store unchanged.
+func (configStore *ConfigStore) hasConfig(configID int64) error {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	_, err := configStore.findConfig(configID)
+	return err
+}
+
+// Compare-and-swap the default configuration identifier.  Fails if another call changed it since canReplaceDefaultConfigID.
+func (configStore *ConfigStore) replaceDefaultConfigID(currentDefaultConfigID int64, newDefaultConfigID int64) error {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	if err := configStore.checkReplacement(currentDefaultConfigID, newDefaultConfigID); err != nil {
+		return err
+	}
+	configStore.defaultConfigID = newDefaultConfigID
+	return nil
+}
+
+// Set the default configuration identifier to a configuration checked by hasConfig.  Configurations are never removed.
+func (configStore *ConfigStore) setDefaultConfigID(configID int64) {
+	configStore.mutex.Lock()
+	defer configStore.mutex.Unlock()
+	configStore.defaultConfigID = configID
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func checkConfigDefinition(configDefinition string) error {
+	if !json.Valid([]byte(configDefinition)) {
+		return helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "invalid configuration: not a JSON document")
+	}
+	return nil
+}
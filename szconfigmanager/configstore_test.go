@@ -0,0 +1,200 @@
+package szconfigmanager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/szconfig"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	configNotFoundErrorCode  = 7221
+	replaceConflictErrorCode = 7245
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestConfigStore_AddConfig(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	configID1, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "First")
+	require.NoError(test, err)
+	configID2, err := szConfigManager.AddConfig(ctx, `{"G2_CONFIG": {}}`, "Second")
+	require.NoError(test, err)
+	assert.NotEqual(test, configID1, configID2)
+	actual, err := szConfigManager.GetConfig(ctx, configID1)
+	require.NoError(test, err)
+	assert.Equal(test, szconfig.ConfigTemplate, actual)
+	actual, err = szConfigManager.GetConfig(ctx, configID2)
+	require.NoError(test, err)
+	assert.Equal(test, `{"G2_CONFIG": {}}`, actual)
+	_, err = szConfigManager.AddConfig(ctx, badConfigDefinition, "Bad")
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestConfigStore_AddConfig_laterError(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("AddConfig", helper.Response{Error: helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost)})
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+		Responses:   responses,
+	}
+	_, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "First")
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+	actual, err := szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"CONFIGS": []}`, actual)
+	szConfigManager.AddConfigFunc = func(ctx context.Context, configDefinition string, configComment string) (int64, error) {
+		return 1000, nil
+	}
+	configID, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "Second")
+	require.NoError(test, err)
+	assert.Equal(test, int64(1000), configID)
+	_, err = szConfigManager.GetConfig(ctx, 1)
+	require.NoError(test, err)
+}
+
+func TestConfigStore_GetConfig_unknown(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	_, err := szConfigManager.GetConfig(ctx, badConfigID)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+}
+
+func TestConfigStore_GetConfigs(test *testing.T) {
+	ctx := context.TODO()
+	configStore := NewConfigStore()
+	configStore.now = func() time.Time { return time.Date(2023, 2, 16, 21, 43, 10, 171000000, time.UTC) }
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: configStore,
+	}
+	actual, err := szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"CONFIGS": []}`, actual)
+	_, err = szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "Example configuration")
+	require.NoError(test, err)
+	_, err = szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "Updated configuration")
+	require.NoError(test, err)
+	actual, err = szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"CONFIGS": [{"CONFIG_ID": 1, "CONFIG_COMMENTS": "Example configuration", "SYS_CREATE_DT": "2023-02-16 21:43:10.171"}, {"CONFIG_ID": 2, "CONFIG_COMMENTS": "Updated configuration", "SYS_CREATE_DT": "2023-02-16 21:43:10.171"}]}`, actual)
+}
+
+func TestConfigStore_SetDefaultConfigID(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	actual, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(0), actual)
+	configID, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "Example configuration")
+	require.NoError(test, err)
+	require.NoError(test, szConfigManager.SetDefaultConfigID(ctx, configID))
+	actual, err = szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, configID, actual)
+}
+
+func TestConfigStore_ReplaceDefaultConfigID(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	configID1, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "First")
+	require.NoError(test, err)
+	configID2, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "Second")
+	require.NoError(test, err)
+	require.NoError(test, szConfigManager.ReplaceDefaultConfigID(ctx, 0, configID1))
+	err = szConfigManager.ReplaceDefaultConfigID(ctx, configID2, configID1)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+	assert.Equal(test, replaceConflictErrorCode, szerror.Code(err.Error()))
+	err = szConfigManager.ReplaceDefaultConfigID(ctx, configID1, 1000)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+	assert.Equal(test, configNotFoundErrorCode, szerror.Code(err.Error()))
+	require.NoError(test, szConfigManager.ReplaceDefaultConfigID(ctx, configID1, configID2))
+	actual, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, configID2, actual)
+}
+
+func TestConfigStore_ReplaceDefaultConfigID_laterError(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	configID, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "First")
+	require.NoError(test, err)
+	szConfigManager.ReplaceDefaultConfigIDFunc = func(ctx context.Context, currentDefaultConfigID int64, newDefaultConfigID int64) error {
+		return helper.NewSzErrorFromCatalog(helper.SzErrorDatabase, "ReplaceDefaultConfigIDFunc")
+	}
+	err = szConfigManager.ReplaceDefaultConfigID(ctx, 0, configID)
+	require.ErrorIs(test, err, szerror.ErrSzDatabase)
+	szConfigManager.SetDefaultConfigIDFunc = func(ctx context.Context, configID int64) error {
+		return helper.NewSzErrorFromCatalog(helper.SzErrorDatabase, "SetDefaultConfigIDFunc")
+	}
+	err = szConfigManager.SetDefaultConfigID(ctx, configID)
+	require.ErrorIs(test, err, szerror.ErrSzDatabase)
+	actual, err := szConfigManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(0), actual)
+}
+
+func TestConfigStore_ReplaceDefaultConfigID_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	configIDs := []int64{}
+	for range make([]struct{}, 8) {
+		configID, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, "Candidate")
+		require.NoError(test, err)
+		configIDs = append(configIDs, configID)
+	}
+	results := make([]error, len(configIDs))
+	var waitGroup sync.WaitGroup
+	for index, configID := range configIDs {
+		waitGroup.Add(1)
+		go func(index int, configID int64) {
+			defer waitGroup.Done()
+			results[index] = szConfigManager.ReplaceDefaultConfigID(ctx, 0, configID)
+		}(index, configID)
+	}
+	waitGroup.Wait()
+	winners := 0
+	for index, err := range results {
+		if err == nil {
+			winners++
+			actual, err := szConfigManager.GetDefaultConfigID(ctx)
+			require.NoError(test, err)
+			assert.Equal(test, configIDs[index], actual)
+			continue
+		}
+		assert.Equal(test, replaceConflictErrorCode, szerror.Code(err.Error()))
+	}
+	assert.Equal(test, 1, winners)
+}
+
+func TestConfigStore_SetDefaultConfigID_unknown(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	err := szConfigManager.SetDefaultConfigID(ctx, badConfigID)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+	err = szConfigManager.ReplaceDefaultConfigID(ctx, badCurrentDefaultConfigID, badConfigID)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+}
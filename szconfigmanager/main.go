@@ -6,3 +6,6 @@ package szconfigmanager
 
 // Identfier of the szconfigmanager package found messages having the format "senzing-6032xxxx".
 const ComponentID = 6032
+
+// Name of the szconfigmanager package used in reports of helper.Chaos.
+const componentName = "szconfigmanager"
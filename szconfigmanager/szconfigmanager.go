@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/senzing-garage/go-logging/logging"
@@ -14,18 +16,49 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szconfigmanager"
 )
 
 type Szconfigmanager struct {
-	AddConfigResult          int64
-	GetConfigResult          string
-	GetConfigsResult         string
-	GetDefaultConfigIDResult int64
-	isTrace                  bool
-	logger                   logging.Logging
-	observerOrigin           string
-	observers                subject.Subject
+	AddConfigError              error
+	AddConfigFunc               func(ctx context.Context, configDefinition string, configComment string) (int64, error)
+	AddConfigResult             int64
+	calls                       helper.CallRecorder
+	Chaos                       *helper.Chaos
+	ConfigStore                 *ConfigStore
+	DestroyError                error
+	DestroyFunc                 func(ctx context.Context) error
+	Expectations                *helper.Expectations
+	GetConfigError              error
+	GetConfigFunc               func(ctx context.Context, configID int64) (string, error)
+	GetConfigResult             string
+	GetConfigsError             error
+	GetConfigsFunc              func(ctx context.Context) (string, error)
+	GetConfigsResult            string
+	GetDefaultConfigIDError     error
+	GetDefaultConfigIDFunc      func(ctx context.Context) (int64, error)
+	GetDefaultConfigIDResult    int64
+	HonorContext                bool
+	InitializeError             error
+	InitializeFunc              func(ctx context.Context, instanceName string, settings string, verboseLogging int64) error
+	isTrace                     atomic.Bool
+	Latencies                   *helper.Latencies
+	lifecycle                   helper.Lifecycle
+	logger                      logging.Logging
+	mutex                       sync.RWMutex
+	notifications               helper.Notifications
+	observerOrigin              string
+	observers                   subject.Subject
+	ReplaceDefaultConfigIDError error
+	ReplaceDefaultConfigIDFunc  func(ctx context.Context, currentDefaultConfigID int64, newDefaultConfigID int64) error
+	Responses                   *helper.ResponseQueues
+	ResponseTables              *helper.ResponseTables
+	SetDefaultConfigIDError     error
+	SetDefaultConfigIDFunc      func(ctx context.Context, configID int64) error
+	StrictLifecycle             bool
+	SynchronousNotifications    bool
+	Triggers                    *helper.Triggers
 }
 
 const (
@@ -53,21 +86,46 @@ Output
 func (client *Szconfigmanager) AddConfig(ctx context.Context, configDefinition string, configComment string) (int64, error) {
 	var err error
 	result := client.AddConfigResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(1, configDefinition, configComment)
 		defer func() {
 			client.traceExit(2, configDefinition, configComment, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "AddConfig", err)
+	if err == nil {
+		err = client.AddConfigError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "AddConfig", err)
+		err = helper.TriggeredError(client.Triggers, "AddConfig", err)
+		isValid := false
+		if err == nil && client.ConfigStore != nil {
+			err = checkConfigDefinition(configDefinition)
+			isValid = err == nil
+		}
+		result, err = helper.QueuedResult(client.Responses, "AddConfig", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "AddConfig", result, err, configDefinition, configComment)
+		if client.AddConfigFunc != nil {
+			result, err = client.AddConfigFunc(ctx, configDefinition, configComment)
+		}
+		if err == nil && isValid {
+			configID := client.ConfigStore.addConfig(configDefinition, configComment)
+			if client.AddConfigFunc == nil && result == client.AddConfigResult {
+				result = configID
+			}
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"configComment": configComment,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8001, err, details)
+		})
 	}
+	client.record("AddConfig", senzing.SzNoFlags, result, err, configDefinition, configComment)
 	return result, err
 }
 
@@ -80,17 +138,31 @@ Input
 */
 func (client *Szconfigmanager) Destroy(ctx context.Context) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(5)
 		defer func() { client.traceExit(6, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Destroy", err)
+	if err == nil {
+		err = client.DestroyError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Destroy", err)
+		err = helper.TriggeredError(client.Triggers, "Destroy", err)
+		err = helper.QueuedError(client.Responses, "Destroy", err)
+		err = helper.ExpectedError(client.Expectations, "Destroy", err)
+		if client.DestroyFunc != nil {
+			err = client.DestroyFunc(ctx)
+		}
+		err = client.lifecycle.Destroy(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8002, err, details)
+		})
 	}
+	client.record("Destroy", senzing.SzNoFlags, nil, err)
 	return err
 }
 
@@ -108,17 +180,35 @@ Output
 func (client *Szconfigmanager) GetConfig(ctx context.Context, configID int64) (string, error) {
 	var err error
 	result := client.GetConfigResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(7, configID)
 		defer func() { client.traceExit(8, configID, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetConfig", err)
+	if err == nil {
+		err = client.GetConfigError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetConfig", err)
+		err = helper.TriggeredError(client.Triggers, "GetConfig", err)
+		if err == nil && client.ConfigStore != nil {
+			result, err = client.ConfigStore.getConfig(configID)
+		}
+		result, err = helper.TableResult(client.ResponseTables, "GetConfig", result, err, configID)
+		result, err = helper.QueuedResult(client.Responses, "GetConfig", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetConfig", result, err, configID)
+		if client.GetConfigFunc != nil {
+			result, err = client.GetConfigFunc(ctx, configID)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8003, err, details)
+		})
 	}
+	client.record("GetConfig", senzing.SzNoFlags, result, err, configID)
 	return result, err
 }
 
@@ -135,17 +225,34 @@ Output
 func (client *Szconfigmanager) GetConfigs(ctx context.Context) (string, error) {
 	var err error
 	result := client.GetConfigsResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(9)
 		defer func() { client.traceExit(10, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetConfigs", err)
+	if err == nil {
+		err = client.GetConfigsError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetConfigs", err)
+		err = helper.TriggeredError(client.Triggers, "GetConfigs", err)
+		if err == nil && client.ConfigStore != nil {
+			result, err = client.ConfigStore.getConfigs()
+		}
+		result, err = helper.QueuedResult(client.Responses, "GetConfigs", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetConfigs", result, err)
+		if client.GetConfigsFunc != nil {
+			result, err = client.GetConfigsFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8004, err, details)
+		})
 	}
+	client.record("GetConfigs", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -161,17 +268,34 @@ Output
 func (client *Szconfigmanager) GetDefaultConfigID(ctx context.Context) (int64, error) {
 	var err error
 	result := client.GetDefaultConfigIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(11)
 		defer func() { client.traceExit(12, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetDefaultConfigID", err)
+	if err == nil {
+		err = client.GetDefaultConfigIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetDefaultConfigID", err)
+		err = helper.TriggeredError(client.Triggers, "GetDefaultConfigID", err)
+		if err == nil && client.ConfigStore != nil {
+			result = client.ConfigStore.getDefaultConfigID()
+		}
+		result, err = helper.QueuedResult(client.Responses, "GetDefaultConfigID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetDefaultConfigID", result, err)
+		if client.GetDefaultConfigIDFunc != nil {
+			result, err = client.GetDefaultConfigIDFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8005, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8005, err, details)
+		})
 	}
+	client.record("GetDefaultConfigID", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -188,19 +312,41 @@ Input
 */
 func (client *Szconfigmanager) ReplaceDefaultConfigID(ctx context.Context, currentDefaultConfigID int64, newDefaultConfigID int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(19, currentDefaultConfigID, newDefaultConfigID)
 		defer func() { client.traceExit(20, currentDefaultConfigID, newDefaultConfigID, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ReplaceDefaultConfigID", err)
+	if err == nil {
+		err = client.ReplaceDefaultConfigIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ReplaceDefaultConfigID", err)
+		err = helper.TriggeredError(client.Triggers, "ReplaceDefaultConfigID", err)
+		isValid := false
+		if err == nil && client.ConfigStore != nil {
+			err = client.ConfigStore.canReplaceDefaultConfigID(currentDefaultConfigID, newDefaultConfigID)
+			isValid = err == nil
+		}
+		err = helper.QueuedError(client.Responses, "ReplaceDefaultConfigID", err)
+		err = helper.ExpectedError(client.Expectations, "ReplaceDefaultConfigID", err, currentDefaultConfigID, newDefaultConfigID)
+		if client.ReplaceDefaultConfigIDFunc != nil {
+			err = client.ReplaceDefaultConfigIDFunc(ctx, currentDefaultConfigID, newDefaultConfigID)
+		}
+		if err == nil && isValid {
+			err = client.ConfigStore.replaceDefaultConfigID(currentDefaultConfigID, newDefaultConfigID)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"newDefaultConfigID": strconv.FormatInt(newDefaultConfigID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8007, err, details)
+		})
 	}
+	client.record("ReplaceDefaultConfigID", senzing.SzNoFlags, nil, err, currentDefaultConfigID, newDefaultConfigID)
 	return err
 }
 
@@ -214,19 +360,41 @@ Input
 */
 func (client *Szconfigmanager) SetDefaultConfigID(ctx context.Context, configID int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(21, configID)
 		defer func() { client.traceExit(22, configID, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "SetDefaultConfigID", err)
+	if err == nil {
+		err = client.SetDefaultConfigIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "SetDefaultConfigID", err)
+		err = helper.TriggeredError(client.Triggers, "SetDefaultConfigID", err)
+		isValid := false
+		if err == nil && client.ConfigStore != nil {
+			err = client.ConfigStore.hasConfig(configID)
+			isValid = err == nil
+		}
+		err = helper.QueuedError(client.Responses, "SetDefaultConfigID", err)
+		err = helper.ExpectedError(client.Expectations, "SetDefaultConfigID", err, configID)
+		if client.SetDefaultConfigIDFunc != nil {
+			err = client.SetDefaultConfigIDFunc(ctx, configID)
+		}
+		if err == nil && isValid {
+			client.ConfigStore.setDefaultConfigID(configID)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"configID": strconv.FormatInt(configID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8008, err, details)
+		})
 	}
+	client.record("SetDefaultConfigID", senzing.SzNoFlags, nil, err, configID)
 	return err
 }
 
@@ -234,6 +402,34 @@ func (client *Szconfigmanager) SetDefaultConfigID(ctx context.Context, configID
 // Public non-interface methods
 // ----------------------------------------------------------------------------
 
+/*
+The FlushNotifications method waits until observers have received the notifications
+sent asynchronously by the Szconfigmanager object.
+Unnecessary if SynchronousNotifications is set.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - ctx.Err() if ctx was cancelled or expired before the notifications were delivered.
+*/
+func (client *Szconfigmanager) FlushNotifications(ctx context.Context) error {
+	return client.notifications.Wait(ctx)
+}
+
+/*
+The GetCallRecorder method returns the log of calls made to the Szconfigmanager object.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The CallRecorder holding the calls made to the Szconfigmanager object.
+*/
+func (client *Szconfigmanager) GetCallRecorder(ctx context.Context) *helper.CallRecorder {
+	return &client.calls
+}
+
 /*
 The GetObserverOrigin method returns the "origin" value of past Observer messages.
 
@@ -245,6 +441,8 @@ Output
 */
 func (client *Szconfigmanager) GetObserverOrigin(ctx context.Context) string {
 	_ = ctx
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
 	return client.observerOrigin
 }
 
@@ -260,21 +458,35 @@ Input
 */
 func (client *Szconfigmanager) Initialize(ctx context.Context, instanceName string, settings string, verboseLogging int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(17, instanceName, settings, verboseLogging)
 		defer func() { client.traceExit(18, instanceName, settings, verboseLogging, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Initialize", err)
+	if err == nil {
+		err = client.InitializeError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Initialize", err)
+		err = helper.TriggeredError(client.Triggers, "Initialize", err)
+		err = helper.QueuedError(client.Responses, "Initialize", err)
+		err = helper.ExpectedError(client.Expectations, "Initialize", err, instanceName, settings, verboseLogging)
+		if client.InitializeFunc != nil {
+			err = client.InitializeFunc(ctx, instanceName, settings, verboseLogging)
+		}
+		err = client.lifecycle.Initialize(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"instanceName":   instanceName,
 				"settings":       settings,
 				"verboseLogging": strconv.FormatInt(verboseLogging, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8006, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8006, err, details)
+		})
 	}
+	client.record("Initialize", senzing.SzNoFlags, nil, err, instanceName, settings, verboseLogging)
 	return err
 }
 
@@ -287,22 +499,24 @@ Input
 */
 func (client *Szconfigmanager) RegisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(703, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
+	client.mutex.Lock()
 	if client.observers == nil {
-		client.observers = &subject.SimpleSubject{}
+		client.observers = &helper.SynchronousSubject{}
 	}
 	err = client.observers.RegisterObserver(ctx, observer)
-	if client.observers != nil {
-		go func() {
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8702, err, details)
+		})
 	}
 	return err
 }
@@ -316,7 +530,7 @@ Input
 */
 func (client *Szconfigmanager) SetLogLevel(ctx context.Context, logLevelName string) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(705, logLevelName)
 		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
@@ -324,15 +538,17 @@ func (client *Szconfigmanager) SetLogLevel(ctx context.Context, logLevelName str
 	if !logging.IsValidLogLevelName(logLevelName) {
 		return fmt.Errorf("invalid error level: %s", logLevelName)
 	}
+	client.mutex.Lock()
 	err = client.getLogger().SetLogLevel(logLevelName)
-	client.isTrace = (logLevelName == logging.LevelTraceName)
-	if client.observers != nil {
-		go func() {
+	client.isTrace.Store(logLevelName == logging.LevelTraceName)
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8703, err, details)
+		})
 	}
 	return err
 }
@@ -346,6 +562,8 @@ Input
 */
 func (client *Szconfigmanager) SetObserverOrigin(ctx context.Context, origin string) {
 	_ = ctx
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.observerOrigin = origin
 }
 
@@ -358,23 +576,26 @@ Input
 */
 func (client *Szconfigmanager) UnregisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(707, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
+	if observers, observerOrigin := client.getObservers(); observers != nil {
 		// Tricky code:
-		// client.notify is called synchronously before client.observers is set to nil.
-		// In client.notify, each observer will get notified in a goroutine.
-		// Then client.observers may be set to nil, but observer goroutines will be OK.
+		// The observer being removed is notified, before it is removed, in the calling goroutine.
+		// client.mutex is not held, so observers may call the Szconfigmanager object.
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
-		err = client.observers.UnregisterObserver(ctx, observer)
-		if !client.observers.HasObservers(ctx) {
-			client.observers = nil
+		notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8704, err, details)
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		if client.observers != nil {
+			err = client.observers.UnregisterObserver(ctx, observer)
+			if !client.observers.HasObservers(ctx) {
+				client.observers = nil
+			}
 		}
 	}
 	return err
@@ -386,7 +607,7 @@ func (client *Szconfigmanager) UnregisterObserver(ctx context.Context, observer
 
 // --- Logging ----------------------------------------------------------------
 
-// Get the Logger singleton.
+// Get the Logger singleton.  The caller holds client.mutex.
 func (client *Szconfigmanager) getLogger() logging.Logging {
 	if client.logger == nil {
 		client.logger = helper.GetLogger(ComponentID, szconfigmanager.IDMessages, baseCallerSkip)
@@ -394,12 +615,38 @@ func (client *Szconfigmanager) getLogger() logging.Logging {
 	return client.logger
 }
 
+// Get the observers and the origin of their messages.
+func (client *Szconfigmanager) getObservers() (subject.Subject, string) {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.observers, client.observerOrigin
+}
+
 // Trace method entry.
 func (client *Szconfigmanager) traceEntry(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
 
 // Trace method exit.
 func (client *Szconfigmanager) traceExit(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
+
+// ----------------------------------------------------------------------------
+// Call recording
+// ----------------------------------------------------------------------------
+
+// Record the call in the call log.
+func (client *Szconfigmanager) record(method string, flags int64, result interface{}, err error, arguments ...interface{}) {
+	client.calls.Record(helper.Call{
+		Arguments: arguments,
+		Error:     err,
+		Flags:     flags,
+		Method:    method,
+		Result:    result,
+	})
+}
@@ -2,6 +2,7 @@ package szconfigmanager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"testing"
@@ -10,9 +11,10 @@ import (
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/helper/helpertest"
 	"github.com/senzing-garage/sz-sdk-go-mock/szconfig"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
-
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,6 +89,153 @@ func TestSzconfigmanager_GetConfig(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzconfigmanager_GetConfig_error(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		GetConfigError: helper.NewSzErrorFromCatalog(helper.SzErrorConfiguration),
+	}
+	_, err := szConfigManager.GetConfig(ctx, 1)
+	require.ErrorIs(test, err, szerror.ErrSzConfiguration)
+}
+
+func TestSzconfigmanager_GetConfig_func(test *testing.T) {
+	ctx := context.TODO()
+	calls := 0
+	szConfigManager := &Szconfigmanager{
+		GetConfigFunc: func(ctx context.Context, configID int64) (string, error) {
+			_ = ctx
+			calls++
+			return fmt.Sprintf(`{"CONFIG_ID":%d,"CALLS":%d}`, configID, calls), nil
+		},
+		GetConfigResult: "static",
+	}
+	for _, call := range []int{1, 2} {
+		actual, err := szConfigManager.GetConfig(ctx, 1)
+		require.NoError(test, err)
+		assert.Equal(test, fmt.Sprintf(`{"CONFIG_ID":%d,"CALLS":%d}`, 1, call), actual)
+	}
+	assert.Equal(test, 2, szConfigManager.GetCallRecorder(ctx).Count("GetConfig"))
+}
+
+func TestSzconfigmanager_GetConfig_latencies(test *testing.T) {
+	ctx := context.TODO()
+	latencies := helper.NewLatencies(1)
+	latencies.Set(componentName, "GetConfig", helper.FixedLatency(time.Hour))
+	szConfigManager := &Szconfigmanager{
+		Latencies: latencies,
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := szConfigManager.GetConfig(ctxWithTimeout, 1)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	latencies.Set(componentName, "GetConfig", helper.FixedLatency(time.Millisecond))
+	start := time.Now()
+	_, err = szConfigManager.GetConfig(ctx, 1)
+	require.NoError(test, err)
+	assert.GreaterOrEqual(test, time.Since(start), time.Millisecond)
+}
+
+func TestSzconfigmanager_GetConfig_chaos(test *testing.T) {
+	ctx := context.TODO()
+	chaos := helper.NewChaos(1, helper.ChaosSettings{})
+	chaos.Set(componentName, "GetConfig", helper.ChaosSettings{FailureRate: 1})
+	szConfigManager := &Szconfigmanager{
+		Chaos: chaos,
+	}
+	_, err := szConfigManager.GetConfig(ctx, 1)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	report := chaos.Report()
+	assert.Equal(test, map[string]int{helper.SzErrorDatabaseConnectionLost: 1}, report[componentName+".GetConfig"].Faults)
+	chaos.Set(componentName, "GetConfig", helper.ChaosSettings{})
+	_, err = szConfigManager.GetConfig(ctx, 1)
+	require.NoError(test, err)
+}
+
+func TestSzconfigmanager_GetConfig_triggers(test *testing.T) {
+	ctx := context.TODO()
+	triggers := helper.NewTriggers()
+	triggers.Add("GetConfig", helper.OnCall(2), helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost))
+	szConfigManager := &Szconfigmanager{
+		Triggers: triggers,
+	}
+	for call := 1; call <= 3; call++ {
+		_, err := szConfigManager.GetConfig(ctx, 1)
+		if call == 2 {
+			require.ErrorIs(test, err, szerror.ErrSzRetryable)
+		} else {
+			require.NoError(test, err)
+		}
+	}
+	assert.Equal(test, 3, triggers.Calls("GetConfig"))
+}
+
+func TestSzconfigmanager_GetConfig_honorContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	szConfigManager := &Szconfigmanager{
+		HonorContext: true,
+	}
+	_, err := szConfigManager.GetConfig(ctx, 1)
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestSzconfigmanager_GetConfig_strictLifecycle(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		StrictLifecycle: true,
+	}
+	_, err := szConfigManager.GetConfig(ctx, 1)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+	require.NoError(test, szConfigManager.Initialize(ctx, "mock", "{}", senzing.SzNoLogging))
+	require.ErrorIs(test, szConfigManager.Initialize(ctx, "mock", "{}", senzing.SzNoLogging), szerror.ErrSzBadInput)
+	_, err = szConfigManager.GetConfig(ctx, 1)
+	require.NoError(test, err)
+	require.NoError(test, szConfigManager.Destroy(ctx))
+	require.ErrorIs(test, szConfigManager.Destroy(ctx), szerror.ErrSzNotInitialized)
+	_, err = szConfigManager.GetConfig(ctx, 1)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+}
+
+func TestSzconfigmanager_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{
+		ConfigStore: NewConfigStore(),
+	}
+	helpertest.RunConcurrently(ctx, test, szConfigManager, 8, 50, func(worker int, iteration int) error {
+		configID, err := szConfigManager.AddConfig(ctx, szconfig.ConfigTemplate, fmt.Sprintf("Worker %d, iteration %d", worker, iteration))
+		if err != nil {
+			return err
+		}
+		if err = szConfigManager.SetDefaultConfigID(ctx, configID); err != nil {
+			return err
+		}
+		_, err = szConfigManager.GetConfigs(ctx)
+		return err
+	})
+	actual, err := szConfigManager.GetConfigs(ctx)
+	require.NoError(test, err)
+	configs := struct {
+		Configs []interface{} `json:"CONFIGS"`
+	}{}
+	require.NoError(test, json.Unmarshal([]byte(actual), &configs))
+	assert.Len(test, configs.Configs, 8*50)
+}
+
+func TestSzconfigmanager_GetConfig_expectations(test *testing.T) {
+	ctx := context.TODO()
+	expectations := helper.NewExpectations(test)
+	expectations.Expect("GetConfig", 1).Return(`{}`, nil)
+	expectations.Expect("SetDefaultConfigID", 1)
+	szConfigManager := &Szconfigmanager{
+		Expectations: expectations,
+	}
+	actual, err := szConfigManager.GetConfig(ctx, 1)
+	require.NoError(test, err)
+	assert.Equal(test, `{}`, actual)
+	err = szConfigManager.SetDefaultConfigID(ctx, 1)
+	require.NoError(test, err)
+}
+
 func TestSzconfigmanager_GetConfigs(test *testing.T) {
 	ctx := context.TODO()
 	szConfigManager := getTestObject(ctx, test)
@@ -159,6 +308,17 @@ func TestSzconfigmanager_SetObserverOrigin(test *testing.T) {
 	szConfigManager.SetObserverOrigin(ctx, origin)
 }
 
+func TestSzconfigmanager_GetCallRecorder(test *testing.T) {
+	ctx := context.TODO()
+	szConfigManager := &Szconfigmanager{}
+	err := szConfigManager.SetDefaultConfigID(ctx, 2)
+	require.NoError(test, err)
+	lastCall, ok := szConfigManager.GetCallRecorder(ctx).LastCall("SetDefaultConfigID")
+	require.True(test, ok)
+	assert.Equal(test, []interface{}{int64(2)}, lastCall.Arguments)
+	assert.Equal(test, 1, szConfigManager.GetCallRecorder(ctx).Count("SetDefaultConfigID"))
+}
+
 func TestSzconfigmanager_GetObserverOrigin(test *testing.T) {
 	ctx := context.TODO()
 	szConfigManager := getTestObject(ctx, test)
@@ -236,7 +396,7 @@ func getSzConfig(ctx context.Context) (senzing.SzConfig, error) {
 			AddDataSourceResult:  `{"DSRC_ID":1001}`,
 			CreateConfigResult:   1,
 			GetDataSourcesResult: `{"DATA_SOURCES":[{"DSRC_ID":1,"DSRC_CODE":"TEST"},{"DSRC_ID":2,"DSRC_CODE":"SEARCH"}]}`,
-			ExportConfigResult:   `{"G2_CONFIG":{"CFG_ATTR":[{"ATTR_ID":1001,"ATTR_CODE":"DATA_SOURCE","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"Yes","DEFAULT_VALUE":null,"ADVANCED":"Yes","INTERNAL":"No"},{"ATTR_ID":1002,"ATTR_CODE":"ROUTE_CODE",`,
+			ExportConfigResult:   szconfig.ConfigTemplate,
 		}
 		err = szConfigSingleton.SetLogLevel(ctx, logLevel)
 		if err != nil {
@@ -270,7 +430,7 @@ func getSzConfigManager(ctx context.Context) (*Szconfigmanager, error) {
 		}
 		szConfigManagerSingleton = &Szconfigmanager{
 			AddConfigResult:          1,
-			GetConfigResult:          `{"G2_CONFIG":{"CFG_ATTR":[{"ATTR_ID":1001,"ATTR_CODE":"DATA_SOURCE","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"Yes","DEFAULT_VALUE":null,"ADVANCED":"Yes","INTERNAL":"No"},{"ATTR_ID":1002,"ATTR_CODE":"ROUTE_CODE","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"No","DEFAULT_VALUE":null,"ADVANCED":"Yes","INTERNAL":"No"},{"ATTR_ID":1003,"ATTR_CODE":"RECORD_ID","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,"FELEM_CODE":null,"FELEM_REQ":"No","DEFAULT_VALUE":null,"ADVANCED":"No","INTERNAL":"No"},{"ATTR_ID":1004,"ATTR_CODE":"ENTITY_TYPE","ATTR_CLASS":"OBSERVATION","FTYPE_CODE":null,`,
+			GetConfigResult:          szconfig.ConfigTemplate,
 			GetConfigsResult:         `{"CONFIGS":[{"CONFIG_ID":41320074,"CONFIG_COMMENTS":"Example configuration","SYS_CREATE_DT":"2023-02-16 21:43:10.171"},{"CONFIG_ID":1111755672,"CONFIG_COMMENTS":"g2configmgr_test at 2023-02-16 21:43:10.154619801 +0000 UTC","SYS_CREATE_DT":"2023-02-16 21:43:10.159"},{"CONFIG_ID":3680541328,"CONFIG_COMMENTS":"Created by g2diagnostic_test at 2023-02-16 21:43:07.294747409 +0000 UTC","SYS_CREATE_DT":"2023-02-16 21:43:07.755"}]}`,
 			GetDefaultConfigIDResult: 1,
 		}
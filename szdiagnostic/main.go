@@ -6,3 +6,6 @@ package szdiagnostic
 
 // Identfier of the szdiagnostic package found messages having the format "senzing-6033xxxx".
 const ComponentID = 6033
+
+// Name of the szdiagnostic package used in reports of helper.Chaos.
+const componentName = "szdiagnostic"
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/senzing-garage/go-logging/logging"
@@ -14,17 +16,45 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szdiagnostic"
 )
 
 type Szdiagnostic struct {
+	calls                           helper.CallRecorder
+	Chaos                           *helper.Chaos
+	CheckDatastorePerformanceError  error
+	CheckDatastorePerformanceFunc   func(ctx context.Context, secondsToRun int) (string, error)
 	CheckDatastorePerformanceResult string
+	DestroyError                    error
+	DestroyFunc                     func(ctx context.Context) error
+	Expectations                    *helper.Expectations
+	GetDatastoreInfoError           error
+	GetDatastoreInfoFunc            func(ctx context.Context) (string, error)
 	GetDatastoreInfoResult          string
+	GetFeatureError                 error
+	GetFeatureFunc                  func(ctx context.Context, featureID int64) (string, error)
 	GetFeatureResult                string
-	isTrace                         bool
+	HonorContext                    bool
+	InitializeError                 error
+	InitializeFunc                  func(ctx context.Context, instanceName string, settings string, configID int64, verboseLogging int64) error
+	isTrace                         atomic.Bool
+	Latencies                       *helper.Latencies
+	lifecycle                       helper.Lifecycle
 	logger                          logging.Logging
+	mutex                           sync.RWMutex
+	notifications                   helper.Notifications
 	observerOrigin                  string
 	observers                       subject.Subject
+	PurgeRepositoryError            error
+	PurgeRepositoryFunc             func(ctx context.Context) error
+	ReinitializeError               error
+	ReinitializeFunc                func(ctx context.Context, configID int64) error
+	Responses                       *helper.ResponseQueues
+	ResponseTables                  *helper.ResponseTables
+	StrictLifecycle                 bool
+	SynchronousNotifications        bool
+	Triggers                        *helper.Triggers
 }
 
 const (
@@ -53,17 +83,31 @@ Output
 func (client *Szdiagnostic) CheckDatastorePerformance(ctx context.Context, secondsToRun int) (string, error) {
 	var err error
 	result := client.CheckDatastorePerformanceResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(1, secondsToRun)
 		defer func() { client.traceExit(2, secondsToRun, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "CheckDatastorePerformance", err)
+	if err == nil {
+		err = client.CheckDatastorePerformanceError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "CheckDatastorePerformance", err)
+		err = helper.TriggeredError(client.Triggers, "CheckDatastorePerformance", err)
+		result, err = helper.QueuedResult(client.Responses, "CheckDatastorePerformance", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "CheckDatastorePerformance", result, err, secondsToRun)
+		if client.CheckDatastorePerformanceFunc != nil {
+			result, err = client.CheckDatastorePerformanceFunc(ctx, secondsToRun)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8001, err, details)
+		})
 	}
+	client.record("CheckDatastorePerformance", senzing.SzNoFlags, result, err, secondsToRun)
 	return result, err
 }
 
@@ -76,17 +120,31 @@ Input
 */
 func (client *Szdiagnostic) Destroy(ctx context.Context) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(5)
 		defer func() { client.traceExit(6, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Destroy", err)
+	if err == nil {
+		err = client.DestroyError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Destroy", err)
+		err = helper.TriggeredError(client.Triggers, "Destroy", err)
+		err = helper.QueuedError(client.Responses, "Destroy", err)
+		err = helper.ExpectedError(client.Expectations, "Destroy", err)
+		if client.DestroyFunc != nil {
+			err = client.DestroyFunc(ctx)
+		}
+		err = client.lifecycle.Destroy(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8002, err, details)
+		})
 	}
+	client.record("Destroy", senzing.SzNoFlags, nil, err)
 	return err
 }
 
@@ -103,17 +161,31 @@ Output
 func (client *Szdiagnostic) GetDatastoreInfo(ctx context.Context) (string, error) {
 	var err error
 	result := client.GetDatastoreInfoResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(7)
 		defer func() { client.traceExit(8, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetDatastoreInfo", err)
+	if err == nil {
+		err = client.GetDatastoreInfoError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetDatastoreInfo", err)
+		err = helper.TriggeredError(client.Triggers, "GetDatastoreInfo", err)
+		result, err = helper.QueuedResult(client.Responses, "GetDatastoreInfo", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetDatastoreInfo", result, err)
+		if client.GetDatastoreInfoFunc != nil {
+			result, err = client.GetDatastoreInfoFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8003, err, details)
+		})
 	}
+	client.record("GetDatastoreInfo", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -132,19 +204,34 @@ Output
 func (client *Szdiagnostic) GetFeature(ctx context.Context, featureID int64) (string, error) {
 	var err error
 	result := client.GetFeatureResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(9, featureID)
 		defer func() { client.traceExit(10, featureID, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetFeature", err)
+	if err == nil {
+		err = client.GetFeatureError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetFeature", err)
+		err = helper.TriggeredError(client.Triggers, "GetFeature", err)
+		result, err = helper.TableResult(client.ResponseTables, "GetFeature", result, err, featureID)
+		result, err = helper.QueuedResult(client.Responses, "GetFeature", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetFeature", result, err, featureID)
+		if client.GetFeatureFunc != nil {
+			result, err = client.GetFeatureFunc(ctx, featureID)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"featureID": strconv.FormatInt(featureID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8004, err, details)
+		})
 	}
+	client.record("GetFeature", senzing.SzNoFlags, result, err, featureID)
 	return result, err
 }
 
@@ -158,17 +245,31 @@ Input
 */
 func (client *Szdiagnostic) PurgeRepository(ctx context.Context) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(17)
 		defer func() { client.traceExit(18, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "PurgeRepository", err)
+	if err == nil {
+		err = client.PurgeRepositoryError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "PurgeRepository", err)
+		err = helper.TriggeredError(client.Triggers, "PurgeRepository", err)
+		err = helper.QueuedError(client.Responses, "PurgeRepository", err)
+		err = helper.ExpectedError(client.Expectations, "PurgeRepository", err)
+		if client.PurgeRepositoryFunc != nil {
+			err = client.PurgeRepositoryFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8007, err, details)
+		})
 	}
+	client.record("PurgeRepository", senzing.SzNoFlags, nil, err)
 	return err
 }
 
@@ -181,19 +282,33 @@ Input
 */
 func (client *Szdiagnostic) Reinitialize(ctx context.Context, configID int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(19, configID)
 		defer func() { client.traceExit(20, configID, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Reinitialize", err)
+	if err == nil {
+		err = client.ReinitializeError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Reinitialize", err)
+		err = helper.TriggeredError(client.Triggers, "Reinitialize", err)
+		err = helper.QueuedError(client.Responses, "Reinitialize", err)
+		err = helper.ExpectedError(client.Expectations, "Reinitialize", err, configID)
+		if client.ReinitializeFunc != nil {
+			err = client.ReinitializeFunc(ctx, configID)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"configID": strconv.FormatInt(configID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8008, err, details)
+		})
 	}
+	client.record("Reinitialize", senzing.SzNoFlags, nil, err, configID)
 	return err
 }
 
@@ -201,6 +316,34 @@ func (client *Szdiagnostic) Reinitialize(ctx context.Context, configID int64) er
 // Public non-interface methods
 // ----------------------------------------------------------------------------
 
+/*
+The FlushNotifications method waits until observers have received the notifications
+sent asynchronously by the Szdiagnostic object.
+Unnecessary if SynchronousNotifications is set.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - ctx.Err() if ctx was cancelled or expired before the notifications were delivered.
+*/
+func (client *Szdiagnostic) FlushNotifications(ctx context.Context) error {
+	return client.notifications.Wait(ctx)
+}
+
+/*
+The GetCallRecorder method returns the log of calls made to the Szdiagnostic object.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The CallRecorder holding the calls made to the Szdiagnostic object.
+*/
+func (client *Szdiagnostic) GetCallRecorder(ctx context.Context) *helper.CallRecorder {
+	return &client.calls
+}
+
 /*
 The GetObserverOrigin method returns the "origin" value of past Observer messages.
 
@@ -212,6 +355,8 @@ Output
 */
 func (client *Szdiagnostic) GetObserverOrigin(ctx context.Context) string {
 	_ = ctx
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
 	return client.observerOrigin
 }
 
@@ -228,24 +373,38 @@ Input
 */
 func (client *Szdiagnostic) Initialize(ctx context.Context, instanceName string, settings string, configID int64, verboseLogging int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(15, instanceName, settings, configID, verboseLogging)
 		defer func() {
 			client.traceExit(16, instanceName, settings, configID, verboseLogging, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Initialize", err)
+	if err == nil {
+		err = client.InitializeError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Initialize", err)
+		err = helper.TriggeredError(client.Triggers, "Initialize", err)
+		err = helper.QueuedError(client.Responses, "Initialize", err)
+		err = helper.ExpectedError(client.Expectations, "Initialize", err, instanceName, settings, configID, verboseLogging)
+		if client.InitializeFunc != nil {
+			err = client.InitializeFunc(ctx, instanceName, settings, configID, verboseLogging)
+		}
+		err = client.lifecycle.Initialize(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"configID":       strconv.FormatInt(configID, baseTen),
 				"instanceName":   instanceName,
 				"settings":       settings,
 				"verboseLogging": strconv.FormatInt(verboseLogging, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8005, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8005, err, details)
+		})
 	}
+	client.record("Initialize", senzing.SzNoFlags, nil, err, instanceName, settings, configID, verboseLogging)
 	return err
 }
 
@@ -258,22 +417,24 @@ Input
 */
 func (client *Szdiagnostic) RegisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(703, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
+	client.mutex.Lock()
 	if client.observers == nil {
-		client.observers = &subject.SimpleSubject{}
+		client.observers = &helper.SynchronousSubject{}
 	}
 	err = client.observers.RegisterObserver(ctx, observer)
-	if client.observers != nil {
-		go func() {
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8702, err, details)
+		})
 	}
 	return err
 }
@@ -287,7 +448,7 @@ Input
 */
 func (client *Szdiagnostic) SetLogLevel(ctx context.Context, logLevelName string) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(705, logLevelName)
 		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
@@ -295,15 +456,17 @@ func (client *Szdiagnostic) SetLogLevel(ctx context.Context, logLevelName string
 	if !logging.IsValidLogLevelName(logLevelName) {
 		return fmt.Errorf("invalid error level: %s", logLevelName)
 	}
+	client.mutex.Lock()
 	err = client.getLogger().SetLogLevel(logLevelName)
-	client.isTrace = (logLevelName == logging.LevelTraceName)
-	if client.observers != nil {
-		go func() {
+	client.isTrace.Store(logLevelName == logging.LevelTraceName)
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8703, err, details)
+		})
 	}
 	return err
 }
@@ -317,6 +480,8 @@ Input
 */
 func (client *Szdiagnostic) SetObserverOrigin(ctx context.Context, origin string) {
 	_ = ctx
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.observerOrigin = origin
 }
 
@@ -329,23 +494,26 @@ Input
 */
 func (client *Szdiagnostic) UnregisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(707, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
+	if observers, observerOrigin := client.getObservers(); observers != nil {
 		// Tricky code:
-		// client.notify is called synchronously before client.observers is set to nil.
-		// In client.notify, each observer will get notified in a goroutine.
-		// Then client.observers may be set to nil, but observer goroutines will be OK.
+		// The observer being removed is notified, before it is removed, in the calling goroutine.
+		// client.mutex is not held, so observers may call the Szdiagnostic object.
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
-		err = client.observers.UnregisterObserver(ctx, observer)
-		if !client.observers.HasObservers(ctx) {
-			client.observers = nil
+		notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8704, err, details)
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		if client.observers != nil {
+			err = client.observers.UnregisterObserver(ctx, observer)
+			if !client.observers.HasObservers(ctx) {
+				client.observers = nil
+			}
 		}
 	}
 	return err
@@ -357,7 +525,7 @@ func (client *Szdiagnostic) UnregisterObserver(ctx context.Context, observer obs
 
 // --- Logging ----------------------------------------------------------------
 
-// Get the Logger singleton.
+// Get the Logger singleton.  The caller holds client.mutex.
 func (client *Szdiagnostic) getLogger() logging.Logging {
 	if client.logger == nil {
 		client.logger = helper.GetLogger(ComponentID, szdiagnostic.IDMessages, baseCallerSkip)
@@ -365,12 +533,38 @@ func (client *Szdiagnostic) getLogger() logging.Logging {
 	return client.logger
 }
 
+// Get the observers and the origin of their messages.
+func (client *Szdiagnostic) getObservers() (subject.Subject, string) {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.observers, client.observerOrigin
+}
+
 // Trace method entry.
 func (client *Szdiagnostic) traceEntry(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
 
 // Trace method exit.
 func (client *Szdiagnostic) traceExit(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
+
+// ----------------------------------------------------------------------------
+// Call recording
+// ----------------------------------------------------------------------------
+
+// Record the call in the call log.
+func (client *Szdiagnostic) record(method string, flags int64, result interface{}, err error, arguments ...interface{}) {
+	client.calls.Record(helper.Call{
+		Arguments: arguments,
+		Error:     err,
+		Flags:     flags,
+		Method:    method,
+		Result:    result,
+	})
+}
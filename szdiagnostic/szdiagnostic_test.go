@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-helpers/record"
 	"github.com/senzing-garage/go-helpers/truthset"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/helper/helpertest"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,6 +74,143 @@ func TestSzdiagnostic_GetDatastoreInfo(test *testing.T) {
 // TODO: Implement TestSzdiagnostic_GetDatastoreInfo_error
 // func TestSzdiagnostic_GetDatastoreInfo_error(test *testing.T) {}
 
+func TestSzdiagnostic_GetDatastoreInfo_error(test *testing.T) {
+	ctx := context.TODO()
+	szDiagnostic := &Szdiagnostic{
+		GetDatastoreInfoError: helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost),
+	}
+	_, err := szDiagnostic.GetDatastoreInfo(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+}
+
+func TestSzdiagnostic_GetDatastoreInfo_func(test *testing.T) {
+	ctx := context.TODO()
+	calls := 0
+	szDiagnostic := &Szdiagnostic{
+		GetDatastoreInfoFunc: func(ctx context.Context) (string, error) {
+			_ = ctx
+			calls++
+			return fmt.Sprintf(`{"CALLS":%d}`, calls), nil
+		},
+		GetDatastoreInfoResult: "static",
+	}
+	for _, call := range []int{1, 2} {
+		actual, err := szDiagnostic.GetDatastoreInfo(ctx)
+		require.NoError(test, err)
+		assert.Equal(test, fmt.Sprintf(`{"CALLS":%d}`, call), actual)
+	}
+	assert.Equal(test, 2, szDiagnostic.GetCallRecorder(ctx).Count("GetDatastoreInfo"))
+}
+
+func TestSzdiagnostic_GetDatastoreInfo_latencies(test *testing.T) {
+	ctx := context.TODO()
+	latencies := helper.NewLatencies(1)
+	latencies.Set(componentName, "GetDatastoreInfo", helper.FixedLatency(time.Hour))
+	szDiagnostic := &Szdiagnostic{
+		Latencies: latencies,
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := szDiagnostic.GetDatastoreInfo(ctxWithTimeout)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	latencies.Set(componentName, "GetDatastoreInfo", helper.FixedLatency(time.Millisecond))
+	start := time.Now()
+	_, err = szDiagnostic.GetDatastoreInfo(ctx)
+	require.NoError(test, err)
+	assert.GreaterOrEqual(test, time.Since(start), time.Millisecond)
+}
+
+func TestSzdiagnostic_GetDatastoreInfo_chaos(test *testing.T) {
+	ctx := context.TODO()
+	chaos := helper.NewChaos(1, helper.ChaosSettings{})
+	chaos.Set(componentName, "GetDatastoreInfo", helper.ChaosSettings{FailureRate: 1})
+	szDiagnostic := &Szdiagnostic{
+		Chaos: chaos,
+	}
+	_, err := szDiagnostic.GetDatastoreInfo(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	report := chaos.Report()
+	assert.Equal(test, map[string]int{helper.SzErrorDatabaseConnectionLost: 1}, report[componentName+".GetDatastoreInfo"].Faults)
+	chaos.Set(componentName, "GetDatastoreInfo", helper.ChaosSettings{})
+	_, err = szDiagnostic.GetDatastoreInfo(ctx)
+	require.NoError(test, err)
+}
+
+func TestSzdiagnostic_GetDatastoreInfo_triggers(test *testing.T) {
+	ctx := context.TODO()
+	triggers := helper.NewTriggers()
+	triggers.Add("GetDatastoreInfo", helper.OnCall(2), helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost))
+	szDiagnostic := &Szdiagnostic{
+		Triggers: triggers,
+	}
+	for call := 1; call <= 3; call++ {
+		_, err := szDiagnostic.GetDatastoreInfo(ctx)
+		if call == 2 {
+			require.ErrorIs(test, err, szerror.ErrSzRetryable)
+		} else {
+			require.NoError(test, err)
+		}
+	}
+	assert.Equal(test, 3, triggers.Calls("GetDatastoreInfo"))
+}
+
+func TestSzdiagnostic_GetDatastoreInfo_honorContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	szDiagnostic := &Szdiagnostic{
+		HonorContext: true,
+	}
+	_, err := szDiagnostic.GetDatastoreInfo(ctx)
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestSzdiagnostic_GetDatastoreInfo_strictLifecycle(test *testing.T) {
+	ctx := context.TODO()
+	szDiagnostic := &Szdiagnostic{
+		StrictLifecycle: true,
+	}
+	_, err := szDiagnostic.GetDatastoreInfo(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+	require.NoError(test, szDiagnostic.Initialize(ctx, "mock", "{}", senzing.SzInitializeWithDefaultConfiguration, senzing.SzNoLogging))
+	require.ErrorIs(test, szDiagnostic.Initialize(ctx, "mock", "{}", senzing.SzInitializeWithDefaultConfiguration, senzing.SzNoLogging), szerror.ErrSzBadInput)
+	_, err = szDiagnostic.GetDatastoreInfo(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szDiagnostic.Destroy(ctx))
+	require.ErrorIs(test, szDiagnostic.Destroy(ctx), szerror.ErrSzNotInitialized)
+	_, err = szDiagnostic.GetDatastoreInfo(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+}
+
+func TestSzdiagnostic_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	for range make([]struct{}, 8*50) {
+		responses.Enqueue("GetDatastoreInfo", helper.Response{Result: `{"dataStores":[]}`})
+	}
+	responses.SetFallback("GetDatastoreInfo", helper.QueueFallbackFail)
+	szDiagnostic := &Szdiagnostic{
+		Responses: responses,
+	}
+	helpertest.RunConcurrently(ctx, test, szDiagnostic, 8, 50, func(worker int, iteration int) error {
+		_, err := szDiagnostic.GetDatastoreInfo(ctx)
+		return err
+	})
+	assert.Equal(test, 8*50, szDiagnostic.GetCallRecorder(ctx).Count("GetDatastoreInfo"))
+	assert.Equal(test, 0, responses.Len("GetDatastoreInfo"))
+}
+
+func TestSzdiagnostic_GetDatastoreInfo_expectations(test *testing.T) {
+	ctx := context.TODO()
+	expectations := helper.NewExpectations(test)
+	expectations.Expect("GetDatastoreInfo").AnyTimes().Return(`{"dataStores":[]}`, nil)
+	szDiagnostic := &Szdiagnostic{
+		Expectations: expectations,
+	}
+	actual, err := szDiagnostic.GetDatastoreInfo(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, `{"dataStores":[]}`, actual)
+}
+
 func TestSzdiagnostic_GetFeature(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -109,6 +249,16 @@ func TestSzdiagnostic_SetObserverOrigin(test *testing.T) {
 	szDiagnostic.SetObserverOrigin(ctx, origin)
 }
 
+func TestSzdiagnostic_GetCallRecorder(test *testing.T) {
+	ctx := context.TODO()
+	szDiagnostic := &Szdiagnostic{GetFeatureResult: "{}"}
+	_, err := szDiagnostic.GetFeature(ctx, 1)
+	require.NoError(test, err)
+	lastCall, ok := szDiagnostic.GetCallRecorder(ctx).LastCall("GetFeature")
+	require.True(test, ok)
+	assert.Equal(test, "{}", lastCall.Result)
+}
+
 func TestSzdiagnostic_GetObserverOrigin(test *testing.T) {
 	ctx := context.TODO()
 	szDiagnostic := getTestObject(ctx, test)
@@ -0,0 +1,230 @@
+package szengine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+)
+
+// Exports holds the export cursors used by Szengine when its Exports field is set.
+// Entities are exported from the configured entity documents or, if none are configured, from Szengine.Repository.
+// It is safe for concurrent use.
+type Exports struct {
+	cursors          map[uintptr][]string
+	entities         []string
+	mutex            sync.Mutex
+	nextExportHandle uintptr
+}
+
+// The columns of ExportCsvEntityReport() used when csvColumnList is empty.
+var defaultCsvColumns = []string{"RESOLVED_ENTITY_ID", "RELATED_ENTITY_ID", "MATCH_LEVEL", "MATCH_KEY", "DATA_SOURCE", "RECORD_ID"}
+
+// All columns supported by ExportCsvEntityReport(), selected with a csvColumnList of "*".
+var allCsvColumns = []string{"RESOLVED_ENTITY_ID", "RESOLVED_ENTITY_NAME", "RELATED_ENTITY_ID", "MATCH_LEVEL", "MATCH_KEY", "DATA_SOURCE", "RECORD_ID"}
+
+/*
+The NewExports function creates Exports.
+
+Input
+  - entities: Entity documents, in the {"RESOLVED_ENTITY": {...}} form returned by GetEntityByEntityID(), to be exported.
+    If none are given, entities are exported from Szengine.Repository.
+
+Output
+  - Exports to be assigned to Szengine.Exports.
+*/
+func NewExports(entities ...string) *Exports {
+	exports := &Exports{
+		cursors:          map[uintptr][]string{},
+		nextExportHandle: 1,
+	}
+	if len(entities) > 0 {
+		exports.entities = append([]string{}, entities...)
+	}
+	return exports
+}
+
+/*
+The OpenCount method returns the number of export handles not yet closed.
+
+Output
+  - The number of open export handles.
+*/
+func (exports *Exports) OpenCount() int {
+	exports.mutex.Lock()
+	defer exports.mutex.Unlock()
+	return len(exports.cursors)
+}
+
+/*
+The SetEntities method sets the entity documents exported by cursors opened afterwards.
+
+Input
+  - entities: Entity documents, in the {"RESOLVED_ENTITY": {...}} form returned by GetEntityByEntityID().
+    If none are given, entities are exported from Szengine.Repository.
+*/
+func (exports *Exports) SetEntities(entities ...string) {
+	exports.mutex.Lock()
+	defer exports.mutex.Unlock()
+	exports.entities = nil
+	if len(entities) > 0 {
+		exports.entities = append([]string{}, entities...)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (exports *Exports) closeExport(exportHandle uintptr) error {
+	exports.mutex.Lock()
+	defer exports.mutex.Unlock()
+	if _, ok := exports.cursors[exportHandle]; !ok {
+		return helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid export handle %d", exportHandle))
+	}
+	delete(exports.cursors, exportHandle)
+	return nil
+}
+
+func (exports *Exports) exportCsvEntityReport(repository *Repository, csvColumnList string) (uintptr, error) {
+	columns, err := getCsvColumns(csvColumnList)
+	if err != nil {
+		return 0, err
+	}
+	lines := []string{}
+	line, err := formatCsvLine(columns)
+	if err != nil {
+		return 0, err
+	}
+	lines = append(lines, line)
+	for _, entity := range exports.getEntities(repository) {
+		document := entityResponse{}
+		if err := json.Unmarshal([]byte(entity), &document); err != nil {
+			return 0, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid entity document: %v", err))
+		}
+		for _, record := range document.ResolvedEntity.Records {
+			matchLevel := "1"
+			if len(record.MatchKey) == 0 {
+				matchLevel = "0"
+			}
+			values := map[string]string{
+				"DATA_SOURCE":          record.DataSource,
+				"MATCH_KEY":            record.MatchKey,
+				"MATCH_LEVEL":          matchLevel,
+				"RECORD_ID":            record.RecordID,
+				"RELATED_ENTITY_ID":    "0",
+				"RESOLVED_ENTITY_ID":   strconv.FormatInt(document.ResolvedEntity.EntityID, baseTen),
+				"RESOLVED_ENTITY_NAME": document.ResolvedEntity.EntityName,
+			}
+			row := make([]string, len(columns))
+			for i, column := range columns {
+				row[i] = values[column]
+			}
+			line, err := formatCsvLine(row)
+			if err != nil {
+				return 0, err
+			}
+			lines = append(lines, line)
+		}
+	}
+	return exports.open(lines), nil
+}
+
+func (exports *Exports) exportJSONEntityReport(repository *Repository) (uintptr, error) {
+	lines := []string{}
+	for _, entity := range exports.getEntities(repository) {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, []byte(entity)); err != nil {
+			return 0, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid entity document: %v", err))
+		}
+		lines = append(lines, compacted.String()+"\n")
+	}
+	return exports.open(lines), nil
+}
+
+// Return the remaining lines of an export and close it.
+func (exports *Exports) fetchAll(exportHandle uintptr) ([]string, error) {
+	exports.mutex.Lock()
+	defer exports.mutex.Unlock()
+	lines, ok := exports.cursors[exportHandle]
+	if !ok {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid export handle %d", exportHandle))
+	}
+	delete(exports.cursors, exportHandle)
+	return lines, nil
+}
+
+// Return the next line of an export, including its newline.  An empty string means the export is complete.
+func (exports *Exports) fetchNext(exportHandle uintptr) (string, error) {
+	exports.mutex.Lock()
+	defer exports.mutex.Unlock()
+	lines, ok := exports.cursors[exportHandle]
+	if !ok {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid export handle %d", exportHandle))
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	exports.cursors[exportHandle] = lines[1:]
+	return lines[0], nil
+}
+
+func (exports *Exports) getEntities(repository *Repository) []string {
+	exports.mutex.Lock()
+	entities := exports.entities
+	exports.mutex.Unlock()
+	if entities == nil && repository != nil {
+		return repository.getEntities()
+	}
+	return entities
+}
+
+func (exports *Exports) open(lines []string) uintptr {
+	exports.mutex.Lock()
+	defer exports.mutex.Unlock()
+	exportHandle := exports.nextExportHandle
+	exports.nextExportHandle++
+	exports.cursors[exportHandle] = lines
+	return exportHandle
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func formatCsvLine(values []string) (string, error) {
+	var line bytes.Buffer
+	writer := csv.NewWriter(&line)
+	if err := writer.Write(values); err != nil {
+		return "", err
+	}
+	writer.Flush()
+	return line.String(), writer.Error()
+}
+
+func getCsvColumns(csvColumnList string) ([]string, error) {
+	switch strings.TrimSpace(csvColumnList) {
+	case "":
+		return defaultCsvColumns, nil
+	case "*":
+		return allCsvColumns, nil
+	}
+	columns := []string{}
+	for _, column := range strings.Split(csvColumnList, ",") {
+		column = strings.ToUpper(strings.TrimSpace(column))
+		isKnown := false
+		for _, knownColumn := range allCsvColumns {
+			isKnown = isKnown || column == knownColumn
+		}
+		if !isKnown {
+			return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("unknown CSV column %q", column))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
@@ -0,0 +1,281 @@
+package szengine
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestExports_ExportJSONEntityReport(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Exports: NewExports(`{"RESOLVED_ENTITY": {"ENTITY_ID": 1}}`, `{"RESOLVED_ENTITY": {"ENTITY_ID": 2}}`),
+	}
+	exportHandle, err := szEngine.ExportJSONEntityReport(ctx, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, []string{`{"RESOLVED_ENTITY":{"ENTITY_ID":1}}` + "\n", `{"RESOLVED_ENTITY":{"ENTITY_ID":2}}` + "\n"}, fetchAll(ctx, test, szEngine, exportHandle))
+	actual, err := szEngine.FetchNext(ctx, exportHandle)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+	require.NoError(test, szEngine.CloseExport(ctx, exportHandle))
+	assert.Equal(test, 0, szEngine.Exports.OpenCount())
+	_, err = szEngine.FetchNext(ctx, exportHandle)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	require.ErrorIs(test, szEngine.CloseExport(ctx, exportHandle), szerror.ErrSzBadInput)
+}
+
+func TestExports_ExportJSONEntityReport_concurrentHandles(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Exports: NewExports(`{"RESOLVED_ENTITY": {"ENTITY_ID": 1}}`),
+	}
+	exportHandle1, err := szEngine.ExportJSONEntityReport(ctx, senzing.SzNoFlags)
+	require.NoError(test, err)
+	exportHandle2, err := szEngine.ExportJSONEntityReport(ctx, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.NotEqual(test, exportHandle1, exportHandle2)
+	assert.Len(test, fetchAll(ctx, test, szEngine, exportHandle1), 1)
+	assert.Len(test, fetchAll(ctx, test, szEngine, exportHandle2), 1)
+	assert.Equal(test, 2, szEngine.Exports.OpenCount())
+}
+
+func TestExports_ExportJSONEntityReport_withRepository(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.Exports = NewExports()
+	exportHandle, err := szEngine.ExportJSONEntityReport(ctx, senzing.SzNoFlags)
+	require.NoError(test, err)
+	lines := fetchAll(ctx, test, szEngine, exportHandle)
+	require.Len(test, lines, 2)
+	assert.Equal(test, int64(1), getResolvedEntityID(test, lines[0]))
+	assert.Equal(test, int64(2), getResolvedEntityID(test, lines[1]))
+}
+
+func TestExports_ExportCsvEntityReport(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.Exports = NewExports()
+	exportHandle, err := szEngine.ExportCsvEntityReport(ctx, "", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, []string{
+		"RESOLVED_ENTITY_ID,RELATED_ENTITY_ID,MATCH_LEVEL,MATCH_KEY,DATA_SOURCE,RECORD_ID\n",
+		"1,0,0,,CUSTOMERS,1001\n",
+		"1,0,1,+NAME_FULL+DATE_OF_BIRTH,CUSTOMERS,1002\n",
+		"1,0,1,+EMAIL_ADDRESS,REFERENCE,2001\n",
+		"2,0,0,,CUSTOMERS,1003\n",
+	}, fetchAll(ctx, test, szEngine, exportHandle))
+}
+
+func TestExports_ExportCsvEntityReport_csvColumnList(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.Exports = NewExports()
+	exportHandle, err := szEngine.ExportCsvEntityReport(ctx, "resolved_entity_id, RESOLVED_ENTITY_NAME,RECORD_ID", senzing.SzNoFlags)
+	require.NoError(test, err)
+	lines := fetchAll(ctx, test, szEngine, exportHandle)
+	require.Len(test, lines, 5)
+	assert.Equal(test, "RESOLVED_ENTITY_ID,RESOLVED_ENTITY_NAME,RECORD_ID\n", lines[0])
+	assert.Equal(test, "2,Edward Kusha,1003\n", lines[4])
+	_, err = szEngine.ExportCsvEntityReport(ctx, "RESOLVED_ENTITY_ID,BOB", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestExports_FetchNext_unknownExportHandle(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Exports: NewExports(),
+	}
+	_, err := szEngine.FetchNext(ctx, 99)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestExports_SetEntities(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.Exports = NewExports()
+	szEngine.Exports.SetEntities(`{"RESOLVED_ENTITY": {"ENTITY_ID": 9}}`)
+	exportHandle, err := szEngine.ExportJSONEntityReport(ctx, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Len(test, fetchAll(ctx, test, szEngine, exportHandle), 1)
+	szEngine.Exports.SetEntities()
+	exportHandle, err = szEngine.ExportJSONEntityReport(ctx, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Len(test, fetchAll(ctx, test, szEngine, exportHandle), 2)
+}
+
+func TestExports_ExportCsvEntityReportIterator(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.Exports = NewExports()
+	actual := []string{}
+	for fragment := range szEngine.ExportCsvEntityReportIterator(ctx, "RESOLVED_ENTITY_ID,RECORD_ID", senzing.SzNoFlags) {
+		require.NoError(test, fragment.Error)
+		actual = append(actual, fragment.Value)
+	}
+	assert.Equal(test, []string{"RESOLVED_ENTITY_ID,RECORD_ID\n", "1,1001\n", "1,1002\n", "1,2001\n", "2,1003\n"}, actual)
+	assert.Equal(test, 0, szEngine.Exports.OpenCount())
+}
+
+func TestExports_ExportCsvEntityReportIterator_badCsvColumnList(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Exports: NewExports(),
+	}
+	fragments := []senzing.StringFragment{}
+	for fragment := range szEngine.ExportCsvEntityReportIterator(ctx, "BOB", senzing.SzNoFlags) {
+		fragments = append(fragments, fragment)
+	}
+	require.Len(test, fragments, 1)
+	require.ErrorIs(test, fragments[0].Error, szerror.ErrSzBadInput)
+}
+
+func TestExports_ExportJSONEntityReportIterator(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.Exports = NewExports()
+	actual := []string{}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		require.NoError(test, fragment.Error)
+		actual = append(actual, fragment.Value)
+	}
+	require.Len(test, actual, 2)
+	assert.Equal(test, int64(2), getResolvedEntityID(test, actual[1]))
+}
+
+func TestExports_ExportJSONEntityReportIterator_error(test *testing.T) {
+	ctx := context.TODO()
+	expectedErr := helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost)
+	szEngine := &Szengine{
+		ExportJSONEntityReportIteratorError:  expectedErr,
+		ExportJSONEntityReportIteratorResult: []string{"{}\n", "{}\n"},
+	}
+	fragments := []senzing.StringFragment{}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		fragments = append(fragments, fragment)
+	}
+	require.Len(test, fragments, 3)
+	assert.Equal(test, "{}\n", fragments[1].Value)
+	require.NoError(test, fragments[1].Error)
+	require.ErrorIs(test, fragments[2].Error, expectedErr)
+}
+
+func TestExports_ExportJSONEntityReportIterator_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	fragments := make([]string, 1000)
+	szEngine := &Szengine{
+		ExportJSONEntityReportIteratorResult: fragments,
+	}
+	stringFragmentChannel := szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags)
+	<-stringFragmentChannel
+	cancel()
+	received := 1
+	var lastFragment senzing.StringFragment
+	require.Eventually(test, func() bool {
+		fragment, ok := <-stringFragmentChannel
+		if ok {
+			received++
+			lastFragment = fragment
+		}
+		return !ok
+	}, time.Second, time.Millisecond)
+	assert.Less(test, received, len(fragments))
+	require.ErrorIs(test, lastFragment.Error, context.Canceled)
+}
+
+func TestExports_ExportJSONEntityReportIterator_cancelWithoutReading(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	goroutines := runtime.NumGoroutine()
+	szEngine := &Szengine{
+		ExportJSONEntityReportIteratorResult: make([]string, 1000),
+	}
+	stringFragmentChannel := szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags)
+	cancel()
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > goroutines && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	require.LessOrEqual(test, runtime.NumGoroutine(), goroutines)
+	fragment, ok := <-stringFragmentChannel
+	require.True(test, ok)
+	require.ErrorIs(test, fragment.Error, context.Canceled)
+	_, ok = <-stringFragmentChannel
+	assert.False(test, ok)
+}
+
+func TestExports_ExportCsvEntityReportIterator_layers(test *testing.T) {
+	ctx := context.TODO()
+	triggers := helper.NewTriggers()
+	triggers.Add("ExportCsvEntityReportIterator", helper.OnCall(1), helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost))
+	szEngine := &Szengine{
+		ExportCsvEntityReportIteratorFunc: func(ctx context.Context, csvColumnList string, flags int64) ([]string, error) {
+			return []string{csvColumnList + "\n"}, nil
+		},
+		ExportCsvEntityReportIteratorResult: []string{"ignored\n"},
+		Triggers:                            triggers,
+	}
+	fragments := []senzing.StringFragment{}
+	for fragment := range szEngine.ExportCsvEntityReportIterator(ctx, "RESOLVED_ENTITY_ID", senzing.SzNoFlags) {
+		fragments = append(fragments, fragment)
+	}
+	require.Len(test, fragments, 1)
+	assert.Equal(test, "RESOLVED_ENTITY_ID\n", fragments[0].Value)
+	lastCall, ok := szEngine.GetCallRecorder(ctx).LastCall("ExportCsvEntityReportIterator")
+	require.True(test, ok)
+	assert.Equal(test, []interface{}{"RESOLVED_ENTITY_ID", senzing.SzNoFlags}, lastCall.Arguments)
+}
+
+func TestExports_ExportJSONEntityReportIterator_layers(test *testing.T) {
+	ctx := context.TODO()
+	expectedErr := helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost)
+	triggers := helper.NewTriggers()
+	triggers.Add("ExportJSONEntityReportIterator", helper.OnCall(1), expectedErr)
+	responses := &helper.ResponseQueues{}
+	szEngine := &Szengine{
+		Exports:   NewExports(),
+		Responses: responses,
+		Triggers:  triggers,
+	}
+	fragments := []senzing.StringFragment{}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		fragments = append(fragments, fragment)
+	}
+	require.Len(test, fragments, 1)
+	require.ErrorIs(test, fragments[0].Error, expectedErr)
+	assert.Equal(test, 0, szEngine.Exports.OpenCount())
+	responses.Enqueue("ExportJSONEntityReportIterator", helper.Response{Result: []string{"{}\n"}})
+	fragments = []senzing.StringFragment{}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		fragments = append(fragments, fragment)
+	}
+	require.Len(test, fragments, 1)
+	assert.Equal(test, "{}\n", fragments[0].Value)
+	assert.Equal(test, 2, szEngine.GetCallRecorder(ctx).Count("ExportJSONEntityReportIterator"))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func fetchAll(ctx context.Context, test *testing.T, szEngine *Szengine, exportHandle uintptr) []string {
+	result := []string{}
+	for {
+		line, err := szEngine.FetchNext(ctx, exportHandle)
+		require.NoError(test, err)
+		if len(line) == 0 {
+			return result
+		}
+		result = append(result, line)
+	}
+}
@@ -6,3 +6,6 @@ package szengine
 
 // Identfier of the szengine package found messages having the format "senzing-6034xxxx".
 const ComponentID = 6034
+
+// Name of the szengine package used in reports of helper.Chaos.
+const componentName = "szengine"
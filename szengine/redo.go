@@ -0,0 +1,143 @@
+package szengine
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// RedoQueue is an in-memory queue of redo records used by Szengine when its RedoQueue field is set.
+// It is safe for concurrent use.
+type RedoQueue struct {
+	cascades    map[string][]string
+	mutex       sync.Mutex
+	popped      []string
+	processed   []string
+	redoRecords []string
+}
+
+type redoRecordResponse struct {
+	Reason     string `json:"REASON"`
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+	DsrcAction string `json:"DSRC_ACTION"`
+}
+
+/*
+The NewRedoQueue function creates a RedoQueue.
+
+Input
+  - redoRecords: Redo records to seed the queue with, in the order GetRedoRecord() returns them.
+
+Output
+  - A RedoQueue to be assigned to Szengine.RedoQueue.
+*/
+func NewRedoQueue(redoRecords ...string) *RedoQueue {
+	return &RedoQueue{
+		cascades:    map[string][]string{},
+		redoRecords: append([]string{}, redoRecords...),
+	}
+}
+
+/*
+The Add method appends redo records to the queue.
+
+Input
+  - redoRecords: The redo records.
+*/
+func (redoQueue *RedoQueue) Add(redoRecords ...string) {
+	redoQueue.mutex.Lock()
+	defer redoQueue.mutex.Unlock()
+	redoQueue.redoRecords = append(redoQueue.redoRecords, redoRecords...)
+}
+
+/*
+The Len method returns the number of redo records waiting in the queue.
+
+Output
+  - The number of redo records.
+*/
+func (redoQueue *RedoQueue) Len() int {
+	redoQueue.mutex.Lock()
+	defer redoQueue.mutex.Unlock()
+	return len(redoQueue.redoRecords)
+}
+
+/*
+The Processed method returns the redo records passed to ProcessRedoRecord(), in order.
+
+Output
+  - The processed redo records.
+*/
+func (redoQueue *RedoQueue) Processed() []string {
+	redoQueue.mutex.Lock()
+	defer redoQueue.mutex.Unlock()
+	return append([]string{}, redoQueue.processed...)
+}
+
+/*
+The SetCascade method makes processing a redo record add follow-up redo records to the queue.
+
+Input
+  - redoRecord: The redo record triggering the cascade.
+  - followUpRedoRecords: The redo records added when redoRecord is processed.
+*/
+func (redoQueue *RedoQueue) SetCascade(redoRecord string, followUpRedoRecords ...string) {
+	redoQueue.mutex.Lock()
+	defer redoQueue.mutex.Unlock()
+	redoQueue.cascades[redoRecord] = followUpRedoRecords
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Add a "deferred delete" redo record for a deleted record.
+func (redoQueue *RedoQueue) addDeferredDelete(dataSourceCode string, recordID string) {
+	redoRecord, _ := json.Marshal(redoRecordResponse{ // A struct of strings always marshals.
+		Reason:     "deferred delete",
+		DataSource: strings.ToUpper(dataSourceCode),
+		RecordID:   recordID,
+		DsrcAction: "X",
+	})
+	redoQueue.Add(string(redoRecord))
+}
+
+// Remove the first redo record from the queue.  An empty string means the queue is empty.
+func (redoQueue *RedoQueue) pop() string {
+	redoQueue.mutex.Lock()
+	defer redoQueue.mutex.Unlock()
+	if len(redoQueue.redoRecords) == 0 {
+		return ""
+	}
+	result := redoQueue.redoRecords[0]
+	redoQueue.redoRecords = redoQueue.redoRecords[1:]
+	redoQueue.popped = append(redoQueue.popped, result)
+	return result
+}
+
+// Consume a redo record, removing it from the queue unless GetRedoRecord() already popped it, and add its cascade.
+func (redoQueue *RedoQueue) process(redoRecord string) {
+	redoQueue.mutex.Lock()
+	defer redoQueue.mutex.Unlock()
+	if !removeFirst(&redoQueue.popped, redoRecord) {
+		removeFirst(&redoQueue.redoRecords, redoRecord)
+	}
+	redoQueue.processed = append(redoQueue.processed, redoRecord)
+	redoQueue.redoRecords = append(redoQueue.redoRecords, redoQueue.cascades[redoRecord]...)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Remove the first occurrence of a redo record from a list.  Returns false if the list does not contain it.
+func removeFirst(redoRecords *[]string, redoRecord string) bool {
+	for i, candidate := range *redoRecords {
+		if candidate == redoRecord {
+			*redoRecords = append((*redoRecords)[:i], (*redoRecords)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
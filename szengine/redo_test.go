@@ -0,0 +1,131 @@
+package szengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestRedoQueue_ProcessRedoRecord(test *testing.T) {
+	ctx := context.TODO()
+	redoQueue := NewRedoQueue("redo-1", "redo-2")
+	redoQueue.SetCascade("redo-1", "redo-1a", "redo-1b")
+	szEngine := &Szengine{
+		RedoQueue: redoQueue,
+	}
+	processed := []string{}
+	for {
+		count, err := szEngine.CountRedoRecords(ctx)
+		require.NoError(test, err)
+		if count == 0 {
+			break
+		}
+		redoRecord, err := szEngine.GetRedoRecord(ctx)
+		require.NoError(test, err)
+		require.NotEmpty(test, redoRecord)
+		_, err = szEngine.ProcessRedoRecord(ctx, redoRecord, senzing.SzNoFlags)
+		require.NoError(test, err)
+		processed = append(processed, redoRecord)
+	}
+	assert.Equal(test, []string{"redo-1", "redo-2", "redo-1a", "redo-1b"}, processed)
+	assert.Equal(test, processed, redoQueue.Processed())
+	actual, err := szEngine.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestRedoQueue_ProcessRedoRecord_notPopped(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		RedoQueue: NewRedoQueue("redo-1", "redo-2"),
+	}
+	_, err := szEngine.ProcessRedoRecord(ctx, "redo-2", senzing.SzNoFlags)
+	require.NoError(test, err)
+	actual, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), actual)
+	szEngine.RedoQueue.Add("redo-3")
+	assert.Equal(test, 2, szEngine.RedoQueue.Len())
+}
+
+func TestRedoQueue_ProcessRedoRecord_duplicates(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		RedoQueue: NewRedoQueue("redo-1", "redo-1"),
+	}
+	redoRecord, err := szEngine.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	_, err = szEngine.ProcessRedoRecord(ctx, redoRecord, senzing.SzNoFlags)
+	require.NoError(test, err)
+	actual, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), actual)
+}
+
+func TestRedoQueue_GetRedoRecord_laterError(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("GetRedoRecord", helper.Response{Error: helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost)})
+	szEngine := &Szengine{
+		RedoQueue: NewRedoQueue("redo-1"),
+		Responses: responses,
+	}
+	_, err := szEngine.GetRedoRecord(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+	assert.Equal(test, 1, szEngine.RedoQueue.Len())
+	szEngine.GetRedoRecordFunc = func(ctx context.Context) (string, error) {
+		return "GetRedoRecordFunc", nil
+	}
+	actual, err := szEngine.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "GetRedoRecordFunc", actual)
+	assert.Equal(test, 1, szEngine.RedoQueue.Len())
+	szEngine.GetRedoRecordFunc = nil
+	actual, err = szEngine.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, "redo-1", actual)
+	assert.Equal(test, 0, szEngine.RedoQueue.Len())
+}
+
+func TestRedoQueue_ProcessRedoRecord_laterError(test *testing.T) {
+	ctx := context.TODO()
+	redoQueue := NewRedoQueue("redo-1")
+	redoQueue.SetCascade("redo-1", "redo-1a")
+	szEngine := &Szengine{
+		ProcessRedoRecordFunc: func(ctx context.Context, redoRecord string, flags int64) (string, error) {
+			return "", helper.NewSzErrorFromCatalog(helper.SzErrorDatabase, "ProcessRedoRecordFunc")
+		},
+		RedoQueue: redoQueue,
+	}
+	_, err := szEngine.ProcessRedoRecord(ctx, "redo-1", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzDatabase)
+	assert.Equal(test, 1, redoQueue.Len())
+	assert.Empty(test, redoQueue.Processed())
+}
+
+func TestRedoQueue_DeleteRecord(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.RedoQueue = NewRedoQueue()
+	_, err := szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	_, err = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	count, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), count)
+	redoRecord, err := szEngine.GetRedoRecord(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, `{"REASON":"deferred delete","DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","DSRC_ACTION":"X"}`, redoRecord)
+	_, err = szEngine.ProcessRedoRecord(ctx, redoRecord, senzing.SzNoFlags)
+	require.NoError(test, err)
+}
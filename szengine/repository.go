@@ -0,0 +1,257 @@
+package szengine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+)
+
+// Repository is an in-memory Senzing repository used by Szengine when its Repository field is set.
+// Records are resolved into entities using its ResolutionRules.  It is safe for concurrent use.
+type Repository struct {
+	dataSources     map[string]bool
+	entities        map[int64][]recordKey
+	mutex           sync.RWMutex
+	nextEntityID    int64
+	nextSequence    int64
+	records         map[recordKey]*storedRecord
+	resolutionRules []ResolutionRule
+}
+
+type preparedRecord struct {
+	definition string
+	features   map[string]string
+	key        recordKey
+}
+
+type recordKey struct {
+	dataSourceCode string
+	recordID       string
+}
+
+type storedRecord struct {
+	definition string
+	entityID   int64
+	features   map[string]string
+	matchKey   string
+	sequence   int64
+}
+
+type recordResponse struct {
+	DataSource string          `json:"DATA_SOURCE"`
+	RecordID   string          `json:"RECORD_ID"`
+	JSONData   json.RawMessage `json:"JSON_DATA"`
+}
+
+/*
+The NewRepository function creates an empty Repository accepting records from the given data sources.
+
+Input
+  - dataSourceCodes: The data sources registered in the active configuration (e.g. "CUSTOMERS").
+
+Output
+  - An empty Repository to be assigned to Szengine.Repository.
+*/
+func NewRepository(dataSourceCodes ...string) *Repository {
+	repository := &Repository{
+		dataSources:  map[string]bool{},
+		entities:     map[int64][]recordKey{},
+		nextEntityID: 1,
+		records:      map[recordKey]*storedRecord{},
+	}
+	for _, dataSourceCode := range dataSourceCodes {
+		repository.dataSources[strings.ToUpper(dataSourceCode)] = true
+	}
+	return repository
+}
+
+/*
+The NewRepositoryFromConfig function creates an empty Repository accepting records from
+the data sources registered in a Senzing configuration.
+
+Input
+  - configDefinition: A Senzing configuration JSON document (e.g. from SzConfig.ExportConfig()).
+
+Output
+  - An empty Repository to be assigned to Szengine.Repository.
+*/
+func NewRepositoryFromConfig(configDefinition string) (*Repository, error) {
+	config := struct {
+		G2Config struct {
+			CfgDsrc []struct {
+				DsrcCode string `json:"DSRC_CODE"`
+			} `json:"CFG_DSRC"`
+		} `json:"G2_CONFIG"`
+	}{}
+	if err := json.Unmarshal([]byte(configDefinition), &config); err != nil {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, err)
+	}
+	dataSourceCodes := []string{}
+	for _, dataSource := range config.G2Config.CfgDsrc {
+		dataSourceCodes = append(dataSourceCodes, dataSource.DsrcCode)
+	}
+	return NewRepository(dataSourceCodes...), nil
+}
+
+/*
+The RecordCount method returns the number of records in the Repository.
+
+Output
+  - The number of records.
+*/
+func (repository *Repository) RecordCount() int {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	return len(repository.records)
+}
+
+/*
+The RegisterDataSource method allows records from an additional data source.
+
+Input
+  - dataSourceCode: The data source to be registered (e.g. "CUSTOMERS").
+*/
+func (repository *Repository) RegisterDataSource(dataSourceCode string) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	repository.dataSources[strings.ToUpper(dataSourceCode)] = true
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Delete a record whose key was returned by getRecordKey.
+func (repository *Repository) deleteRecord(key recordKey) []int64 {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	record, ok := repository.records[key]
+	if !ok {
+		return []int64{}
+	}
+	delete(repository.records, key)
+	affectedEntityIDs := repository.resolve()
+	affectedEntityIDs[record.entityID] = true
+	return getSortedEntityIDs(affectedEntityIDs)
+}
+
+func (repository *Repository) getRecord(dataSourceCode string, recordID string) (string, error) {
+	key, err := repository.getRecordKey(dataSourceCode, recordID)
+	if err != nil {
+		return "", err
+	}
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	record, err := repository.getStoredRecord(key)
+	if err != nil {
+		return "", err
+	}
+	response, err := json.Marshal(recordResponse{
+		DataSource: key.dataSourceCode,
+		RecordID:   key.recordID,
+		JSONData:   json.RawMessage(record.definition),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(response), nil
+}
+
+// Normalize the record key and verify its data source is registered.
+func (repository *Repository) getRecordKey(dataSourceCode string, recordID string) (recordKey, error) {
+	key := recordKey{
+		dataSourceCode: strings.ToUpper(dataSourceCode),
+		recordID:       recordID,
+	}
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	if !repository.dataSources[key.dataSourceCode] {
+		return key, helper.NewSzErrorFromCatalog(helper.SzErrorUnknownDataSource, dataSourceCode)
+	}
+	if len(recordID) == 0 {
+		return key, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "missing RECORD_ID")
+	}
+	return key, nil
+}
+
+// Get a record that is expected to exist.  The caller holds the mutex.
+func (repository *Repository) getStoredRecord(key recordKey) (*storedRecord, error) {
+	record, ok := repository.records[key]
+	if !ok {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorRecordNotFound, key.dataSourceCode, key.recordID)
+	}
+	return record, nil
+}
+
+/*
+Validate a record to be added, leaving the repository unchanged.
+
+Input
+  - dataSourceCode: Identifies the provenance of the data.
+  - recordID: The unique identifier within the records of the same data source.
+  - recordDefinition: A JSON document containing the record.
+
+Output
+  - The validated record, to be stored by putRecord.
+*/
+func (repository *Repository) prepareRecord(dataSourceCode string, recordID string, recordDefinition string) (*preparedRecord, error) {
+	key, err := repository.getRecordKey(dataSourceCode, recordID)
+	if err != nil {
+		return nil, err
+	}
+	record := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(recordDefinition), &record); err != nil {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid record definition: %v", err))
+	}
+	if value, ok := record["DATA_SOURCE"]; ok && !strings.EqualFold(fmt.Sprint(value), key.dataSourceCode) {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("data source code [%v] does not match [%s]", value, key.dataSourceCode))
+	}
+	if value, ok := record["RECORD_ID"]; ok && fmt.Sprint(value) != key.recordID {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("record ID [%v] does not match [%s]", value, key.recordID))
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(recordDefinition)); err != nil {
+		return nil, helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, err)
+	}
+	return &preparedRecord{
+		definition: compacted.String(),
+		features:   getFeatures(record),
+		key:        key,
+	}, nil
+}
+
+/*
+Store a record validated by prepareRecord and resolve the repository, under a single lock.
+
+Input
+  - prepared: The validated record.
+
+Output
+  - The ENTITY_IDs affected by the change.
+*/
+func (repository *Repository) putRecord(prepared *preparedRecord) []int64 {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	affectedEntityIDs := map[int64]bool{}
+	if previous, ok := repository.records[prepared.key]; ok {
+		affectedEntityIDs[previous.entityID] = true
+		previous.definition = prepared.definition
+		previous.features = prepared.features
+	} else {
+		repository.nextSequence++
+		repository.records[prepared.key] = &storedRecord{
+			definition: prepared.definition,
+			features:   prepared.features,
+			sequence:   repository.nextSequence,
+		}
+	}
+	for entityID := range repository.resolve() {
+		affectedEntityIDs[entityID] = true
+	}
+	affectedEntityIDs[repository.records[prepared.key].entityID] = true
+	return getSortedEntityIDs(affectedEntityIDs)
+}
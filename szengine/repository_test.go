@@ -0,0 +1,190 @@
+package szengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestRepository_AddRecord(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Repository: NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAME_FULL": "Robert Smith"}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, 1, szEngine.Repository.RecordCount())
+	actual, err := szEngine.GetRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","JSON_DATA":{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","NAME_FULL":"Robert Smith"}}`, actual)
+}
+
+func TestRepository_AddRecord_replace(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Repository: NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"NAME_FULL": "Robert Smith"}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"NAME_FULL": "Bob Smith"}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, 1, szEngine.Repository.RecordCount())
+	actual, err := szEngine.GetRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Contains(test, actual, "Bob Smith")
+}
+
+func TestRepository_AddRecord_badRecordDefinition(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Repository: NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `}{`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"DATA_SOURCE": "REFERENCE"}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"RECORD_ID": "1002"}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Equal(test, 0, szEngine.Repository.RecordCount())
+}
+
+func TestRepository_AddRecord_unknownDataSource(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Repository: NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "BOB", "1001", `{}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+	_, err = szEngine.GetRecord(ctx, "BOB", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+	_, err = szEngine.DeleteRecord(ctx, "BOB", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+	szEngine.Repository.RegisterDataSource("bob")
+	_, err = szEngine.AddRecord(ctx, "BOB", "1001", `{}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+}
+
+func TestRepository_AddRecord_error(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		AddRecordError: helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost),
+		Repository:     NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzNoFlags)
+	require.Error(test, err)
+	assert.Equal(test, 0, szEngine.Repository.RecordCount())
+}
+
+func TestRepository_AddRecord_laterError(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("AddRecord", helper.Response{Error: helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost)})
+	szEngine := &Szengine{
+		GenerateWithInfo: true,
+		Repository:       NewRepository("CUSTOMERS"),
+		Responses:        responses,
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzWithInfo)
+	require.ErrorIs(test, err, szerror.ErrSzDatabaseConnectionLost)
+	assert.Equal(test, 0, szEngine.Repository.RecordCount())
+	szEngine.AddRecordFunc = func(ctx context.Context, dataSourceCode string, recordID string, recordDefinition string, flags int64) (string, error) {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorDatabase, "AddRecordFunc")
+	}
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzWithInfo)
+	require.ErrorIs(test, err, szerror.ErrSzDatabase)
+	assert.Equal(test, 0, szEngine.Repository.RecordCount())
+	szEngine.AddRecordFunc = nil
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.Equal(test, 1, szEngine.Repository.RecordCount())
+}
+
+func TestRepository_DeleteRecord(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Repository: NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{"NAME_FULL": "Robert Smith"}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	_, err = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	_, err = szEngine.GetRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	_, err = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+}
+
+func TestRepository_DeleteRecord_laterError(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		DeleteRecordFunc: func(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
+			return "", helper.NewSzErrorFromCatalog(helper.SzErrorDatabase, "DeleteRecordFunc")
+		},
+		RedoQueue:  NewRedoQueue(),
+		Repository: NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	_, err = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzDatabase)
+	assert.Equal(test, 1, szEngine.Repository.RecordCount())
+	redoRecords, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(0), redoRecords)
+}
+
+func TestRepository_WithInfo_committed(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		GenerateWithInfo: true,
+		Repository:       NewRepository("CUSTOMERS"),
+	}
+	actual, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	actual, err = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+}
+
+func TestRepository_WithInfo_funcResult(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		AddRecordFunc: func(ctx context.Context, dataSourceCode string, recordID string, recordDefinition string, flags int64) (string, error) {
+			return "AddRecordFunc", nil
+		},
+		GenerateWithInfo: true,
+		Repository:       NewRepository("CUSTOMERS"),
+	}
+	actual, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.Equal(test, "AddRecordFunc", actual)
+	assert.Equal(test, 1, szEngine.Repository.RecordCount())
+}
+
+func TestRepository_NewRepositoryFromConfig(test *testing.T) {
+	ctx := context.TODO()
+	repository, err := NewRepositoryFromConfig(`{"G2_CONFIG": {"CFG_DSRC": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 2, "DSRC_CODE": "SEARCH"}]}}`)
+	require.NoError(test, err)
+	szEngine := &Szengine{
+		Repository: repository,
+	}
+	_, err = szEngine.AddRecord(ctx, "TEST", "1", `{}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1", `{}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzUnknownDataSource)
+}
+
+func TestRepository_NewRepositoryFromConfig_badConfigDefinition(test *testing.T) {
+	_, err := NewRepositoryFromConfig(`}{`)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
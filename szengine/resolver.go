@@ -0,0 +1,435 @@
+package szengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+)
+
+// A ResolutionRule lists record attributes (e.g. "NAME_FULL", "DATE_OF_BIRTH") whose normalized values,
+// when all present and equal, resolve two records into the same entity.
+type ResolutionRule []string
+
+// DefaultResolutionRules resolve records on full name and date of birth, SSN, or email address.
+var DefaultResolutionRules = []ResolutionRule{
+	{"NAME_FULL", "DATE_OF_BIRTH"},
+	{"SSN_NUMBER"},
+	{"EMAIL_ADDRESS"},
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^0-9A-Z]+`)
+
+type entityRecord struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+	MatchKey   string `json:"MATCH_KEY"`
+}
+
+type entityResponse struct {
+	ResolvedEntity  resolvedEntity `json:"RESOLVED_ENTITY"`
+	RelatedEntities []interface{}  `json:"RELATED_ENTITIES"`
+}
+
+type recordSummary struct {
+	DataSource  string `json:"DATA_SOURCE"`
+	RecordCount int    `json:"RECORD_COUNT"`
+}
+
+type resolvedEntity struct {
+	EntityID      int64           `json:"ENTITY_ID"`
+	EntityName    string          `json:"ENTITY_NAME"`
+	RecordSummary []recordSummary `json:"RECORD_SUMMARY"`
+	Records       []entityRecord  `json:"RECORDS"`
+}
+
+/*
+The SetResolutionRules method sets the rules used to resolve records into entities
+and re-resolves all records.  Without rules, every record is its own entity.
+
+Input
+  - resolutionRules: The rules (e.g. DefaultResolutionRules).
+*/
+func (repository *Repository) SetResolutionRules(resolutionRules ...ResolutionRule) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	repository.resolutionRules = resolutionRules
+	repository.resolve()
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (repository *Repository) getEntityByEntityID(entityID int64) (string, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	keys, ok := repository.entities[entityID]
+	if !ok {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorEntityNotFound, entityID)
+	}
+	return repository.getEntityResponse(entityID, keys, nil)
+}
+
+// Get the documents of all entities, ordered by ENTITY_ID.
+func (repository *Repository) getEntities() []string {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	entityIDs := make(map[int64]bool, len(repository.entities))
+	for entityID := range repository.entities {
+		entityIDs[entityID] = true
+	}
+	result := []string{}
+	for _, entityID := range getSortedEntityIDs(entityIDs) {
+		entity, err := repository.getEntityResponse(entityID, repository.entities[entityID], nil)
+		if err == nil {
+			result = append(result, entity)
+		}
+	}
+	return result
+}
+
+func (repository *Repository) getEntityByRecordID(dataSourceCode string, recordID string) (string, error) {
+	key, err := repository.getRecordKey(dataSourceCode, recordID)
+	if err != nil {
+		return "", err
+	}
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	record, err := repository.getStoredRecord(key)
+	if err != nil {
+		return "", err
+	}
+	return repository.getEntityResponse(record.entityID, repository.entities[record.entityID], nil)
+}
+
+func (repository *Repository) getVirtualEntityByRecordID(recordList string) (string, error) {
+	recordKeys := struct {
+		Records []struct {
+			DataSource string `json:"DATA_SOURCE"`
+			RecordID   string `json:"RECORD_ID"`
+		} `json:"RECORDS"`
+	}{}
+	if err := json.Unmarshal([]byte(recordList), &recordKeys); err != nil {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid record keys: %v", err))
+	}
+	if len(recordKeys.Records) == 0 {
+		return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "no records")
+	}
+	keys := []recordKey{}
+	for _, record := range recordKeys.Records {
+		key, err := repository.getRecordKey(record.DataSource, record.RecordID)
+		if err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+	}
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	entityID := int64(0)
+	for _, key := range keys {
+		record, err := repository.getStoredRecord(key)
+		if err != nil {
+			return "", err
+		}
+		if entityID == 0 || record.entityID < entityID {
+			entityID = record.entityID
+		}
+	}
+	_, matchKeys := repository.match(keys)
+	return repository.getEntityResponse(entityID, keys, matchKeys)
+}
+
+func (repository *Repository) reevaluateEntity(entityID int64) (recordKey, []int64, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	keys, ok := repository.entities[entityID]
+	if !ok {
+		return recordKey{}, nil, helper.NewSzErrorFromCatalog(helper.SzErrorEntityNotFound, entityID)
+	}
+	key := repository.sortBySequence(keys)[0]
+	affectedEntityIDs := repository.resolve()
+	affectedEntityIDs[repository.records[key].entityID] = true
+	return key, getSortedEntityIDs(affectedEntityIDs), nil
+}
+
+// Reevaluate the record of a redo record, if it is still in the repository.
+func (repository *Repository) processRedoRecord(dataSourceCode string, recordID string) []int64 {
+	affectedEntityIDs, err := repository.reevaluateRecord(dataSourceCode, recordID)
+	if err != nil {
+		return []int64{} // Redo records of deleted records have no effect.
+	}
+	return affectedEntityIDs
+}
+
+func (repository *Repository) reevaluateRecord(dataSourceCode string, recordID string) ([]int64, error) {
+	key, err := repository.getRecordKey(dataSourceCode, recordID)
+	if err != nil {
+		return nil, err
+	}
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	record, err := repository.getStoredRecord(key)
+	if err != nil {
+		return nil, err
+	}
+	affectedEntityIDs := repository.resolve()
+	affectedEntityIDs[record.entityID] = true
+	return getSortedEntityIDs(affectedEntityIDs), nil
+}
+
+// Build a RESOLVED_ENTITY document.  matchKeys overrides the stored match keys.  The caller holds the mutex.
+func (repository *Repository) getEntityResponse(entityID int64, keys []recordKey, matchKeys map[recordKey]string) (string, error) {
+	keys = repository.sortBySequence(keys)
+	entity := resolvedEntity{
+		EntityID:      entityID,
+		RecordSummary: []recordSummary{},
+		Records:       []entityRecord{},
+	}
+	recordCounts := map[string]int{}
+	for _, key := range keys {
+		record := repository.records[key]
+		if len(entity.EntityName) == 0 {
+			entity.EntityName = getEntityName(record.features)
+		}
+		matchKey := record.matchKey
+		if matchKeys != nil {
+			matchKey = matchKeys[key]
+		}
+		entity.Records = append(entity.Records, entityRecord{
+			DataSource: key.dataSourceCode,
+			RecordID:   key.recordID,
+			MatchKey:   matchKey,
+		})
+		recordCounts[key.dataSourceCode]++
+	}
+	sort.Slice(entity.Records, func(i, j int) bool {
+		if entity.Records[i].DataSource != entity.Records[j].DataSource {
+			return entity.Records[i].DataSource < entity.Records[j].DataSource
+		}
+		return entity.Records[i].RecordID < entity.Records[j].RecordID
+	})
+	for dataSourceCode, recordCount := range recordCounts {
+		entity.RecordSummary = append(entity.RecordSummary, recordSummary{
+			DataSource:  dataSourceCode,
+			RecordCount: recordCount,
+		})
+	}
+	sort.Slice(entity.RecordSummary, func(i, j int) bool {
+		return entity.RecordSummary[i].DataSource < entity.RecordSummary[j].DataSource
+	})
+	response, err := json.Marshal(entityResponse{
+		ResolvedEntity:  entity,
+		RelatedEntities: []interface{}{},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(response), nil
+}
+
+/*
+Group records into entities using the resolution rules.
+Records are considered in the order they were added, so the result is deterministic.
+The caller holds the mutex.
+
+Output
+  - The groups of records, each in the order the records were added.
+  - The MATCH_KEY of each record, describing the rule that resolved it into its group.
+*/
+func (repository *Repository) match(keys []recordKey) ([][]recordKey, map[recordKey]string) {
+	keys = repository.sortBySequence(keys)
+	parents := map[recordKey]recordKey{}
+	var find func(key recordKey) recordKey
+	find = func(key recordKey) recordKey {
+		parent, ok := parents[key]
+		if !ok || parent == key {
+			return key
+		}
+		root := find(parent)
+		parents[key] = root
+		return root
+	}
+	matchKeys := map[recordKey]string{}
+	firstRecords := map[string]recordKey{}
+	for _, key := range keys {
+		matchKeys[key] = ""
+		features := repository.records[key].features
+		for ruleIndex, resolutionRule := range repository.resolutionRules {
+			value, ok := resolutionRule.getValue(features)
+			if !ok {
+				continue
+			}
+			index := fmt.Sprintf("%d|%s", ruleIndex, value)
+			firstRecord, ok := firstRecords[index]
+			if !ok {
+				firstRecords[index] = key
+				continue
+			}
+			parents[find(key)] = find(firstRecord)
+			if len(matchKeys[key]) == 0 {
+				matchKeys[key] = resolutionRule.matchKey()
+			}
+		}
+	}
+	groups := [][]recordKey{}
+	groupIndexes := map[recordKey]int{}
+	for _, key := range keys {
+		root := find(key)
+		groupIndex, ok := groupIndexes[root]
+		if !ok {
+			groupIndex = len(groups)
+			groupIndexes[root] = groupIndex
+			groups = append(groups, []recordKey{})
+		}
+		groups[groupIndex] = append(groups[groupIndex], key)
+	}
+	return groups, matchKeys
+}
+
+/*
+Re-resolve all records and assign ENTITY_IDs.
+A merged entity keeps the lowest ENTITY_ID of the entities merged;
+when an entity splits, the part holding the lowest ENTITY_ID keeps it and the other parts get new ones.
+The caller holds the mutex.
+
+Output
+  - The ENTITY_IDs of entities gaining or losing records, not including the loss of a deleted record.
+*/
+func (repository *Repository) resolve() map[int64]bool {
+	keys := make([]recordKey, 0, len(repository.records))
+	for key := range repository.records {
+		keys = append(keys, key)
+	}
+	groups, matchKeys := repository.match(keys)
+	lowestEntityID := func(group []recordKey) int64 {
+		result := int64(0)
+		for _, key := range group {
+			entityID := repository.records[key].entityID
+			if entityID != 0 && (result == 0 || entityID < result) {
+				result = entityID
+			}
+		}
+		return result
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		entityIDi, entityIDj := lowestEntityID(groups[i]), lowestEntityID(groups[j])
+		if entityIDi == 0 || entityIDj == 0 {
+			return entityIDj == 0 && entityIDi != 0
+		}
+		return entityIDi < entityIDj
+	})
+	assigned := map[int64]bool{}
+	entities := map[int64][]recordKey{}
+	for _, group := range groups {
+		entityID := int64(0)
+		for _, key := range group {
+			candidate := repository.records[key].entityID
+			if candidate != 0 && !assigned[candidate] && (entityID == 0 || candidate < entityID) {
+				entityID = candidate
+			}
+		}
+		if entityID == 0 {
+			entityID = repository.nextEntityID
+			repository.nextEntityID++
+		}
+		assigned[entityID] = true
+		entities[entityID] = group
+	}
+	affectedEntityIDs := map[int64]bool{}
+	for entityID, group := range entities {
+		for _, key := range group {
+			record := repository.records[key]
+			if record.entityID != entityID {
+				if record.entityID != 0 {
+					affectedEntityIDs[record.entityID] = true
+				}
+				affectedEntityIDs[entityID] = true
+			}
+			record.entityID = entityID
+			record.matchKey = matchKeys[key]
+		}
+	}
+	repository.entities = entities
+	return affectedEntityIDs
+}
+
+// Order records by the time they were added.  The caller holds the mutex.
+func (repository *Repository) sortBySequence(keys []recordKey) []recordKey {
+	result := make([]recordKey, len(keys))
+	copy(result, keys)
+	sort.Slice(result, func(i, j int) bool {
+		return repository.records[result[i]].sequence < repository.records[result[j]].sequence
+	})
+	return result
+}
+
+// Get the normalized value of the rule's attributes.
+func (resolutionRule ResolutionRule) getValue(features map[string]string) (string, bool) {
+	if len(resolutionRule) == 0 {
+		return "", false
+	}
+	values := make([]string, len(resolutionRule))
+	for i, attribute := range resolutionRule {
+		value := normalize(features[strings.ToUpper(attribute)])
+		if len(value) == 0 {
+			return "", false
+		}
+		values[i] = value
+	}
+	return strings.Join(values, "|"), true
+}
+
+func (resolutionRule ResolutionRule) matchKey() string {
+	var result strings.Builder
+	for _, attribute := range resolutionRule {
+		result.WriteString("+")
+		result.WriteString(strings.ToUpper(attribute))
+	}
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getEntityName(features map[string]string) string {
+	for _, attribute := range []string{"NAME_FULL", "NAME_ORG"} {
+		if value, ok := features[attribute]; ok && len(value) > 0 {
+			return value
+		}
+	}
+	return strings.TrimSpace(features["NAME_FIRST"] + " " + features["NAME_LAST"])
+}
+
+// Get ENTITY_IDs in ascending order.
+func getSortedEntityIDs(entityIDs map[int64]bool) []int64 {
+	result := make([]int64, 0, len(entityIDs))
+	for entityID := range entityIDs {
+		result = append(result, entityID)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// Get the top-level attributes of a record as strings.
+func getFeatures(record map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for attribute, value := range record {
+		switch typedValue := value.(type) {
+		case string:
+			result[strings.ToUpper(attribute)] = typedValue
+		case float64, bool:
+			result[strings.ToUpper(attribute)] = fmt.Sprint(typedValue)
+		}
+	}
+	return result
+}
+
+// Upper-case a value and remove punctuation and whitespace.
+func normalize(value string) string {
+	return nonAlphanumeric.ReplaceAllString(strings.ToUpper(value), "")
+}
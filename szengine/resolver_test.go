@@ -0,0 +1,160 @@
+package szengine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var resolverRecords = []struct {
+	DataSource string
+	ID         string
+	JSON       string
+}{
+	{"CUSTOMERS", "1001", `{"NAME_FULL": "Robert Smith", "DATE_OF_BIRTH": "1985-02-12", "EMAIL_ADDRESS": "bsmith@example.com"}`},
+	{"CUSTOMERS", "1002", `{"NAME_FULL": "ROBERT  SMITH", "DATE_OF_BIRTH": "1985-02-12"}`},
+	{"REFERENCE", "2001", `{"NAME_FULL": "Bob Smith", "EMAIL_ADDRESS": "BSmith@Example.com"}`},
+	{"CUSTOMERS", "1003", `{"NAME_FULL": "Edward Kusha", "SSN_NUMBER": "294-66-9999"}`},
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestResolver_GetEntityByRecordID(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	actual, err := szEngine.GetEntityByRecordID(ctx, "REFERENCE", "2001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{
+		"RESOLVED_ENTITY": {
+			"ENTITY_ID": 1,
+			"ENTITY_NAME": "Robert Smith",
+			"RECORD_SUMMARY": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_COUNT": 2}, {"DATA_SOURCE": "REFERENCE", "RECORD_COUNT": 1}],
+			"RECORDS": [
+				{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "MATCH_KEY": ""},
+				{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002", "MATCH_KEY": "+NAME_FULL+DATE_OF_BIRTH"},
+				{"DATA_SOURCE": "REFERENCE", "RECORD_ID": "2001", "MATCH_KEY": "+EMAIL_ADDRESS"}
+			]
+		},
+		"RELATED_ENTITIES": []
+	}`, actual)
+	actual, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1003", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), getResolvedEntityID(test, actual))
+	_, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "9999", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestResolver_GetEntityByEntityID(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	expected, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	actual, err := szEngine.GetEntityByEntityID(ctx, 1, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+	_, err = szEngine.GetEntityByEntityID(ctx, 3, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestResolver_DeleteRecord_split(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	_, err := szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	actual, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1002", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), getResolvedEntityID(test, actual))
+	actual, err = szEngine.GetEntityByRecordID(ctx, "REFERENCE", "2001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), getResolvedEntityID(test, actual))
+	actual, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1003", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), getResolvedEntityID(test, actual))
+}
+
+func TestResolver_AddRecord_merge(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	_, err := szEngine.AddRecord(ctx, "REFERENCE", "2002", `{"EMAIL_ADDRESS": "bsmith@example.com", "SSN_NUMBER": "294669999"}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	actual, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1003", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), getResolvedEntityID(test, actual))
+	_, err = szEngine.GetEntityByEntityID(ctx, 2, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestResolver_GetVirtualEntityByRecordID(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	actual, err := szEngine.GetVirtualEntityByRecordID(ctx, `{"RECORDS": [{"DATA_SOURCE": "REFERENCE", "RECORD_ID": "2001"}, {"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1003"}]}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), getResolvedEntityID(test, actual))
+	assert.Contains(test, actual, `"RECORD_ID":"1003","MATCH_KEY":""`)
+	_, err = szEngine.GetVirtualEntityByRecordID(ctx, `{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "9999"}]}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	_, err = szEngine.GetVirtualEntityByRecordID(ctx, `}{`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+}
+
+func TestResolver_ReevaluateRecord(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.Repository.SetResolutionRules(ResolutionRule{"EMAIL_ADDRESS"})
+	actual, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1002", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), getResolvedEntityID(test, actual))
+	_, err = szEngine.ReevaluateRecord(ctx, "CUSTOMERS", "1002", senzing.SzNoFlags)
+	require.NoError(test, err)
+	actual, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1002", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, int64(3), getResolvedEntityID(test, actual))
+	_, err = szEngine.ReevaluateRecord(ctx, "CUSTOMERS", "9999", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
+func TestResolver_noResolutionRules(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Repository: NewRepository("CUSTOMERS", "REFERENCE"),
+	}
+	for _, record := range resolverRecords {
+		_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzNoFlags)
+		require.NoError(test, err)
+	}
+	for i, record := range resolverRecords {
+		actual, err := szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzNoFlags)
+		require.NoError(test, err)
+		assert.Equal(test, int64(i+1), getResolvedEntityID(test, actual))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getResolvedEntityID(test *testing.T, entity string) int64 {
+	document := entityResponse{}
+	require.NoError(test, json.Unmarshal([]byte(entity), &document))
+	return document.ResolvedEntity.EntityID
+}
+
+func getResolverTestObject(ctx context.Context, test *testing.T) *Szengine {
+	repository := NewRepository("CUSTOMERS", "REFERENCE")
+	repository.SetResolutionRules(DefaultResolutionRules...)
+	szEngine := &Szengine{
+		Repository: repository,
+	}
+	for _, record := range resolverRecords {
+		_, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzNoFlags)
+		require.NoError(test, err)
+	}
+	return szEngine
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/senzing-garage/go-logging/logging"
@@ -19,37 +21,124 @@ import (
 )
 
 type Szengine struct {
+	AddRecordError                          error
+	AddRecordFunc                           func(ctx context.Context, dataSourceCode string, recordID string, recordDefinition string, flags int64) (string, error)
 	AddRecordResult                         string
+	calls                                   helper.CallRecorder
+	Chaos                                   *helper.Chaos
+	CloseExportError                        error
+	CloseExportFunc                         func(ctx context.Context, exportHandle uintptr) error
+	CountRedoRecordsError                   error
+	CountRedoRecordsFunc                    func(ctx context.Context) (int64, error)
 	CountRedoRecordsResult                  int64
+	DeleteRecordError                       error
+	DeleteRecordFunc                        func(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error)
 	DeleteRecordResult                      string
+	DestroyError                            error
+	DestroyFunc                             func(ctx context.Context) error
+	Expectations                            *helper.Expectations
 	ExportConfigResult                      string
+	ExportCsvEntityReportError              error
+	ExportCsvEntityReportFunc               func(ctx context.Context, csvColumnList string, flags int64) (uintptr, error)
+	ExportCsvEntityReportIteratorError      error
+	ExportCsvEntityReportIteratorFunc       func(ctx context.Context, csvColumnList string, flags int64) ([]string, error)
+	ExportCsvEntityReportIteratorResult     []string
 	ExportCsvEntityReportResult             uintptr
+	ExportJSONEntityReportError             error
+	ExportJSONEntityReportFunc              func(ctx context.Context, flags int64) (uintptr, error)
+	ExportJSONEntityReportIteratorError     error
+	ExportJSONEntityReportIteratorFunc      func(ctx context.Context, flags int64) ([]string, error)
+	ExportJSONEntityReportIteratorResult    []string
 	ExportJSONEntityReportResult            uintptr
+	Exports                                 *Exports
+	FetchNextError                          error
+	FetchNextFunc                           func(ctx context.Context, exportHandle uintptr) (string, error)
 	FetchNextResult                         string
+	FindInterestingEntitiesByEntityIDError  error
+	FindInterestingEntitiesByEntityIDFunc   func(ctx context.Context, entityID int64, flags int64) (string, error)
 	FindInterestingEntitiesByEntityIDResult string
+	FindInterestingEntitiesByRecordIDError  error
+	FindInterestingEntitiesByRecordIDFunc   func(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error)
 	FindInterestingEntitiesByRecordIDResult string
+	FindNetworkByEntityIDError              error
+	FindNetworkByEntityIDFunc               func(ctx context.Context, entityIDs string, maxDegrees int64, buildOutDegree int64, buildOutMaxEntities int64, flags int64) (string, error)
 	FindNetworkByEntityIDResult             string
+	FindNetworkByRecordIDError              error
+	FindNetworkByRecordIDFunc               func(ctx context.Context, recordKeys string, maxDegrees int64, buildOutDegree int64, buildOutMaxEntities int64, flags int64) (string, error)
 	FindNetworkByRecordIDResult             string
+	FindPathByEntityIDError                 error
+	FindPathByEntityIDFunc                  func(ctx context.Context, startEntityID int64, endEntityID int64, maxDegrees int64, avoidEntityIDs string, requiredDataSources string, flags int64) (string, error)
 	FindPathByEntityIDResult                string
+	FindPathByRecordIDError                 error
+	FindPathByRecordIDFunc                  func(ctx context.Context, startDataSourceCode string, startRecordID string, endDataSourceCode string, endRecordID string, maxDegrees int64, avoidRecordKeys string, requiredDataSources string, flags int64) (string, error)
 	FindPathByRecordIDResult                string
+	GenerateWithInfo                        bool
+	GetActiveConfigIDError                  error
+	GetActiveConfigIDFunc                   func(ctx context.Context) (int64, error)
 	GetActiveConfigIDResult                 int64
+	GetEntityByEntityIDError                error
+	GetEntityByEntityIDFunc                 func(ctx context.Context, entityID int64, flags int64) (string, error)
 	GetEntityByEntityIDResult               string
+	GetEntityByRecordIDError                error
+	GetEntityByRecordIDFunc                 func(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error)
 	GetEntityByRecordIDResult               string
+	GetRecordError                          error
+	GetRecordFunc                           func(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error)
 	GetRecordResult                         string
+	GetRedoRecordError                      error
+	GetRedoRecordFunc                       func(ctx context.Context) (string, error)
 	GetRedoRecordResult                     string
+	GetStatsError                           error
+	GetStatsFunc                            func(ctx context.Context) (string, error)
 	GetStatsResult                          string
+	GetVirtualEntityByRecordIDError         error
+	GetVirtualEntityByRecordIDFunc          func(ctx context.Context, recordKeys string, flags int64) (string, error)
 	GetVirtualEntityByRecordIDResult        string
+	HonorContext                            bool
+	HowEntityByEntityIDError                error
+	HowEntityByEntityIDFunc                 func(ctx context.Context, entityID int64, flags int64) (string, error)
 	HowEntityByEntityIDResult               string
-	isTrace                                 bool
+	InitializeError                         error
+	InitializeFunc                          func(ctx context.Context, instanceName string, settings string, configID int64, verboseLogging int64) error
+	isTrace                                 atomic.Bool
+	Latencies                               *helper.Latencies
+	lifecycle                               helper.Lifecycle
 	logger                                  logging.Logging
+	mutex                                   sync.RWMutex
+	notifications                           helper.Notifications
 	observerOrigin                          string
 	observers                               subject.Subject
+	PrimeEngineError                        error
+	PrimeEngineFunc                         func(ctx context.Context) error
+	ProcessRedoRecordError                  error
+	ProcessRedoRecordFunc                   func(ctx context.Context, redoRecord string, flags int64) (string, error)
 	ProcessRedoRecordResult                 string
+	RedoQueue                               *RedoQueue
+	ReevaluateEntityError                   error
+	ReevaluateEntityFunc                    func(ctx context.Context, entityID int64, flags int64) (string, error)
 	ReevaluateEntityResult                  string
+	ReevaluateRecordError                   error
+	ReevaluateRecordFunc                    func(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error)
 	ReevaluateRecordResult                  string
+	ReinitializeError                       error
+	ReinitializeFunc                        func(ctx context.Context, configID int64) error
+	Repository                              *Repository
+	Responses                               *helper.ResponseQueues
+	ResponseTables                          *helper.ResponseTables
+	SearchByAttributesError                 error
+	SearchByAttributesFunc                  func(ctx context.Context, attributes string, searchProfile string, flags int64) (string, error)
 	SearchByAttributesResult                string
+	StrictLifecycle                         bool
+	SynchronousNotifications                bool
+	Triggers                                *helper.Triggers
+	WhyEntitiesError                        error
+	WhyEntitiesFunc                         func(ctx context.Context, entityID1 int64, entityID2 int64, flags int64) (string, error)
 	WhyEntitiesResult                       string
+	WhyRecordInEntityError                  error
+	WhyRecordInEntityFunc                   func(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error)
 	WhyRecordInEntityResult                 string
+	WhyRecordsError                         error
+	WhyRecordsFunc                          func(ctx context.Context, dataSourceCode1 string, recordID1 string, dataSourceCode2 string, recordID2 string, flags int64) (string, error)
 	WhyRecordsResult                        string
 }
 
@@ -77,22 +166,49 @@ Input
 func (client *Szengine) AddRecord(ctx context.Context, dataSourceCode string, recordID string, recordDefinition string, flags int64) (string, error) {
 	var err error
 	result := client.AddRecordResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(1, dataSourceCode, recordID, recordDefinition, flags)
 		defer func() {
 			client.traceExit(2, dataSourceCode, recordID, recordDefinition, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "AddRecord", err)
+	if err == nil {
+		err = client.AddRecordError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "AddRecord", err)
+		err = helper.TriggeredError(client.Triggers, "AddRecord", err)
+		var prepared *preparedRecord
+		if err == nil && client.Repository != nil {
+			prepared, err = client.Repository.prepareRecord(dataSourceCode, recordID, recordDefinition)
+		}
+		result, err = helper.QueuedResult(client.Responses, "AddRecord", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "AddRecord", result, err, dataSourceCode, recordID, recordDefinition, flags)
+		if client.AddRecordFunc != nil {
+			result, err = client.AddRecordFunc(ctx, dataSourceCode, recordID, recordDefinition, flags)
+		}
+		if err == nil {
+			affectedEntityIDs := []int64{}
+			if prepared != nil {
+				affectedEntityIDs = client.Repository.putRecord(prepared)
+			}
+			if client.GenerateWithInfo && client.AddRecordFunc == nil && result == client.AddRecordResult {
+				result, err = buildWithInfo(dataSourceCode, recordID, affectedEntityIDs, flags)
+			}
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8001, err, details)
+		})
 	}
+	client.record("AddRecord", flags, result, err, dataSourceCode, recordID, recordDefinition, flags)
 	return result, err
 }
 
@@ -107,17 +223,34 @@ Input
 */
 func (client *Szengine) CloseExport(ctx context.Context, exportHandle uintptr) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(5, exportHandle)
 		defer func() { client.traceExit(6, exportHandle, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "CloseExport", err)
+	if err == nil {
+		err = client.CloseExportError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "CloseExport", err)
+		err = helper.TriggeredError(client.Triggers, "CloseExport", err)
+		if err == nil && client.Exports != nil {
+			err = client.Exports.closeExport(exportHandle)
+		}
+		err = helper.QueuedError(client.Responses, "CloseExport", err)
+		err = helper.ExpectedError(client.Expectations, "CloseExport", err, exportHandle)
+		if client.CloseExportFunc != nil {
+			err = client.CloseExportFunc(ctx, exportHandle)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8002, err, details)
+		})
 	}
+	client.record("CloseExport", senzing.SzNoFlags, nil, err, exportHandle)
 	return err
 }
 
@@ -133,17 +266,34 @@ Output
 func (client *Szengine) CountRedoRecords(ctx context.Context) (int64, error) {
 	var err error
 	result := client.CountRedoRecordsResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(7)
 		defer func() { client.traceExit(8, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "CountRedoRecords", err)
+	if err == nil {
+		err = client.CountRedoRecordsError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "CountRedoRecords", err)
+		err = helper.TriggeredError(client.Triggers, "CountRedoRecords", err)
+		if err == nil && client.RedoQueue != nil {
+			result = int64(client.RedoQueue.Len())
+		}
+		result, err = helper.QueuedResult(client.Responses, "CountRedoRecords", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "CountRedoRecords", result, err)
+		if client.CountRedoRecordsFunc != nil {
+			result, err = client.CountRedoRecordsFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8003, err, details)
+		})
 	}
+	client.record("CountRedoRecords", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -159,20 +309,53 @@ Input
 func (client *Szengine) DeleteRecord(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
 	var err error
 	result := client.DeleteRecordResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(9, dataSourceCode, recordID, flags)
 		defer func() { client.traceExit(10, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "DeleteRecord", err)
+	if err == nil {
+		err = client.DeleteRecordError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "DeleteRecord", err)
+		err = helper.TriggeredError(client.Triggers, "DeleteRecord", err)
+		var key *recordKey
+		if err == nil && client.Repository != nil {
+			var validKey recordKey
+			if validKey, err = client.Repository.getRecordKey(dataSourceCode, recordID); err == nil {
+				key = &validKey
+			}
+		}
+		result, err = helper.QueuedResult(client.Responses, "DeleteRecord", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "DeleteRecord", result, err, dataSourceCode, recordID, flags)
+		if client.DeleteRecordFunc != nil {
+			result, err = client.DeleteRecordFunc(ctx, dataSourceCode, recordID, flags)
+		}
+		if err == nil {
+			affectedEntityIDs := []int64{}
+			if key != nil {
+				affectedEntityIDs = client.Repository.deleteRecord(*key)
+			}
+			if client.RedoQueue != nil && len(affectedEntityIDs) > 0 {
+				client.RedoQueue.addDeferredDelete(dataSourceCode, recordID)
+			}
+			if client.GenerateWithInfo && client.DeleteRecordFunc == nil && result == client.DeleteRecordResult {
+				result, err = buildWithInfo(dataSourceCode, recordID, affectedEntityIDs, flags)
+			}
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8004, err, details)
+		})
 	}
+	client.record("DeleteRecord", flags, result, err, dataSourceCode, recordID, flags)
 	return result, err
 }
 
@@ -185,17 +368,31 @@ Input
 */
 func (client *Szengine) Destroy(ctx context.Context) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(11)
 		defer func() { client.traceExit(12, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Destroy", err)
+	if err == nil {
+		err = client.DestroyError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Destroy", err)
+		err = helper.TriggeredError(client.Triggers, "Destroy", err)
+		err = helper.QueuedError(client.Responses, "Destroy", err)
+		err = helper.ExpectedError(client.Expectations, "Destroy", err)
+		if client.DestroyFunc != nil {
+			err = client.DestroyFunc(ctx)
+		}
+		err = client.lifecycle.Destroy(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8005, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8005, err, details)
+		})
 	}
+	client.record("Destroy", senzing.SzNoFlags, nil, err)
 	return err
 }
 
@@ -215,17 +412,34 @@ Output
 func (client *Szengine) ExportCsvEntityReport(ctx context.Context, csvColumnList string, flags int64) (uintptr, error) {
 	var err error
 	result := client.ExportCsvEntityReportResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(13, csvColumnList, flags)
 		defer func() { client.traceExit(14, csvColumnList, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ExportCsvEntityReport", err)
+	if err == nil {
+		err = client.ExportCsvEntityReportError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ExportCsvEntityReport", err)
+		err = helper.TriggeredError(client.Triggers, "ExportCsvEntityReport", err)
+		if err == nil && client.Exports != nil {
+			result, err = client.Exports.exportCsvEntityReport(client.Repository, csvColumnList)
+		}
+		result, err = helper.QueuedResult(client.Responses, "ExportCsvEntityReport", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ExportCsvEntityReport", result, err, csvColumnList, flags)
+		if client.ExportCsvEntityReportFunc != nil {
+			result, err = client.ExportCsvEntityReportFunc(ctx, csvColumnList, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8006, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8006, err, details)
+		})
 	}
+	client.record("ExportCsvEntityReport", flags, result, err, csvColumnList, flags)
 	return result, err
 }
 
@@ -244,22 +458,42 @@ Output
   - A channel of strings that can be iterated over.
 */
 func (client *Szengine) ExportCsvEntityReportIterator(ctx context.Context, csvColumnList string, flags int64) chan senzing.StringFragment {
-	stringFragmentChannel := make(chan senzing.StringFragment)
-	go func() {
-		defer close(stringFragmentChannel)
-		var err error
-		if client.isTrace {
-			entryTime := time.Now()
-			client.traceEntry(15, csvColumnList, flags)
-			defer func() { client.traceExit(16, csvColumnList, flags, err, time.Since(entryTime)) }()
+	var err error
+	var result []string
+	if client.isTrace.Load() {
+		entryTime := time.Now()
+		client.traceEntry(15, csvColumnList, flags)
+		defer func() { client.traceExit(16, csvColumnList, flags, err, time.Since(entryTime)) }()
+	}
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ExportCsvEntityReportIterator", err)
+	if err == nil {
+		result = client.ExportCsvEntityReportIteratorResult
+		err = client.ExportCsvEntityReportIteratorError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ExportCsvEntityReportIterator", err)
+		err = helper.TriggeredError(client.Triggers, "ExportCsvEntityReportIterator", err)
+		if err == nil && result == nil && client.Exports != nil {
+			var exportHandle uintptr
+			if exportHandle, err = client.Exports.exportCsvEntityReport(client.Repository, csvColumnList); err == nil {
+				result, err = client.Exports.fetchAll(exportHandle)
+			}
 		}
-		if client.observers != nil {
-			go func() {
-				details := map[string]string{}
-				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8007, err, details)
-			}()
+		result, err = helper.QueuedResult(client.Responses, "ExportCsvEntityReportIterator", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ExportCsvEntityReportIterator", result, err, csvColumnList, flags)
+		if client.ExportCsvEntityReportIteratorFunc != nil {
+			result, err = client.ExportCsvEntityReportIteratorFunc(ctx, csvColumnList, flags)
 		}
-	}()
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
+			details := map[string]string{}
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8007, err, details)
+		})
+	}
+	client.record("ExportCsvEntityReportIterator", flags, result, err, csvColumnList, flags)
+	stringFragmentChannel := make(chan senzing.StringFragment, 1)
+	go sendFragments(ctx, stringFragmentChannel, result, err)
 	return stringFragmentChannel
 }
 
@@ -278,17 +512,34 @@ Output
 func (client *Szengine) ExportJSONEntityReport(ctx context.Context, flags int64) (uintptr, error) {
 	var err error
 	result := client.ExportJSONEntityReportResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(17, flags)
 		defer func() { client.traceExit(18, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ExportJSONEntityReport", err)
+	if err == nil {
+		err = client.ExportJSONEntityReportError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ExportJSONEntityReport", err)
+		err = helper.TriggeredError(client.Triggers, "ExportJSONEntityReport", err)
+		if err == nil && client.Exports != nil {
+			result, err = client.Exports.exportJSONEntityReport(client.Repository)
+		}
+		result, err = helper.QueuedResult(client.Responses, "ExportJSONEntityReport", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ExportJSONEntityReport", result, err, flags)
+		if client.ExportJSONEntityReportFunc != nil {
+			result, err = client.ExportJSONEntityReportFunc(ctx, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8008, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8008, err, details)
+		})
 	}
+	client.record("ExportJSONEntityReport", flags, result, err, flags)
 	return result, err
 }
 
@@ -306,22 +557,42 @@ Output
   - A channel of strings that can be iterated over.
 */
 func (client *Szengine) ExportJSONEntityReportIterator(ctx context.Context, flags int64) chan senzing.StringFragment {
-	stringFragmentChannel := make(chan senzing.StringFragment)
-	go func() {
-		defer close(stringFragmentChannel)
-		var err error
-		if client.isTrace {
-			entryTime := time.Now()
-			client.traceEntry(19, flags)
-			defer func() { client.traceExit(20, flags, err, time.Since(entryTime)) }()
+	var err error
+	var result []string
+	if client.isTrace.Load() {
+		entryTime := time.Now()
+		client.traceEntry(19, flags)
+		defer func() { client.traceExit(20, flags, err, time.Since(entryTime)) }()
+	}
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ExportJSONEntityReportIterator", err)
+	if err == nil {
+		result = client.ExportJSONEntityReportIteratorResult
+		err = client.ExportJSONEntityReportIteratorError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ExportJSONEntityReportIterator", err)
+		err = helper.TriggeredError(client.Triggers, "ExportJSONEntityReportIterator", err)
+		if err == nil && result == nil && client.Exports != nil {
+			var exportHandle uintptr
+			if exportHandle, err = client.Exports.exportJSONEntityReport(client.Repository); err == nil {
+				result, err = client.Exports.fetchAll(exportHandle)
+			}
 		}
-		if client.observers != nil {
-			go func() {
-				details := map[string]string{}
-				notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8009, err, details)
-			}()
+		result, err = helper.QueuedResult(client.Responses, "ExportJSONEntityReportIterator", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ExportJSONEntityReportIterator", result, err, flags)
+		if client.ExportJSONEntityReportIteratorFunc != nil {
+			result, err = client.ExportJSONEntityReportIteratorFunc(ctx, flags)
 		}
-	}()
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
+			details := map[string]string{}
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8009, err, details)
+		})
+	}
+	client.record("ExportJSONEntityReportIterator", flags, result, err, flags)
+	stringFragmentChannel := make(chan senzing.StringFragment, 1)
+	go sendFragments(ctx, stringFragmentChannel, result, err)
 	return stringFragmentChannel
 }
 
@@ -340,17 +611,34 @@ Output
 func (client *Szengine) FetchNext(ctx context.Context, exportHandle uintptr) (string, error) {
 	var err error
 	result := client.FetchNextResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(21, exportHandle)
 		defer func() { client.traceExit(22, exportHandle, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "FetchNext", err)
+	if err == nil {
+		err = client.FetchNextError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "FetchNext", err)
+		err = helper.TriggeredError(client.Triggers, "FetchNext", err)
+		if err == nil && client.Exports != nil {
+			result, err = client.Exports.fetchNext(exportHandle)
+		}
+		result, err = helper.QueuedResult(client.Responses, "FetchNext", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "FetchNext", result, err, exportHandle)
+		if client.FetchNextFunc != nil {
+			result, err = client.FetchNextFunc(ctx, exportHandle)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8010, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8010, err, details)
+		})
 	}
+	client.record("FetchNext", senzing.SzNoFlags, result, err, exportHandle)
 	return result, err
 }
 
@@ -370,19 +658,34 @@ Output
 func (client *Szengine) FindInterestingEntitiesByEntityID(ctx context.Context, entityID int64, flags int64) (string, error) {
 	var err error
 	result := client.FindInterestingEntitiesByEntityIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(23, entityID, flags)
 		defer func() { client.traceExit(24, entityID, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "FindInterestingEntitiesByEntityID", err)
+	if err == nil {
+		err = client.FindInterestingEntitiesByEntityIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "FindInterestingEntitiesByEntityID", err)
+		err = helper.TriggeredError(client.Triggers, "FindInterestingEntitiesByEntityID", err)
+		result, err = helper.TableResult(client.ResponseTables, "FindInterestingEntitiesByEntityID", result, err, entityID)
+		result, err = helper.QueuedResult(client.Responses, "FindInterestingEntitiesByEntityID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "FindInterestingEntitiesByEntityID", result, err, entityID, flags)
+		if client.FindInterestingEntitiesByEntityIDFunc != nil {
+			result, err = client.FindInterestingEntitiesByEntityIDFunc(ctx, entityID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8011, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8011, err, details)
+		})
 	}
+	client.record("FindInterestingEntitiesByEntityID", flags, result, err, entityID, flags)
 	return result, err
 }
 
@@ -403,22 +706,37 @@ Output
 func (client *Szengine) FindInterestingEntitiesByRecordID(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
 	var err error
 	result := client.FindInterestingEntitiesByRecordIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(25, dataSourceCode, recordID, flags)
 		defer func() {
 			client.traceExit(26, dataSourceCode, recordID, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "FindInterestingEntitiesByRecordID", err)
+	if err == nil {
+		err = client.FindInterestingEntitiesByRecordIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "FindInterestingEntitiesByRecordID", err)
+		err = helper.TriggeredError(client.Triggers, "FindInterestingEntitiesByRecordID", err)
+		result, err = helper.TableResult(client.ResponseTables, "FindInterestingEntitiesByRecordID", result, err, dataSourceCode, recordID)
+		result, err = helper.QueuedResult(client.Responses, "FindInterestingEntitiesByRecordID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "FindInterestingEntitiesByRecordID", result, err, dataSourceCode, recordID, flags)
+		if client.FindInterestingEntitiesByRecordIDFunc != nil {
+			result, err = client.FindInterestingEntitiesByRecordIDFunc(ctx, dataSourceCode, recordID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8012, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8012, err, details)
+		})
 	}
+	client.record("FindInterestingEntitiesByRecordID", flags, result, err, dataSourceCode, recordID, flags)
 	return result, err
 }
 
@@ -442,21 +760,36 @@ Output
 func (client *Szengine) FindNetworkByEntityID(ctx context.Context, entityIDs string, maxDegrees int64, buildOutDegree int64, buildOutMaxEntities int64, flags int64) (string, error) {
 	var err error
 	result := client.FindNetworkByEntityIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(27, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
 		defer func() {
 			client.traceExit(28, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "FindNetworkByEntityID", err)
+	if err == nil {
+		err = client.FindNetworkByEntityIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "FindNetworkByEntityID", err)
+		err = helper.TriggeredError(client.Triggers, "FindNetworkByEntityID", err)
+		result, err = helper.TableResult(client.ResponseTables, "FindNetworkByEntityID", result, err, entityIDs)
+		result, err = helper.QueuedResult(client.Responses, "FindNetworkByEntityID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "FindNetworkByEntityID", result, err, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
+		if client.FindNetworkByEntityIDFunc != nil {
+			result, err = client.FindNetworkByEntityIDFunc(ctx, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"entityIDs": entityIDs,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8013, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8013, err, details)
+		})
 	}
+	client.record("FindNetworkByEntityID", flags, result, err, entityIDs, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
 	return result, err
 }
 
@@ -480,21 +813,36 @@ Output
 func (client *Szengine) FindNetworkByRecordID(ctx context.Context, recordKeys string, maxDegrees int64, buildOutDegree int64, buildOutMaxEntities int64, flags int64) (string, error) {
 	var err error
 	result := client.FindNetworkByRecordIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(39, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
 		defer func() {
 			client.traceExit(40, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "FindNetworkByRecordID", err)
+	if err == nil {
+		err = client.FindNetworkByRecordIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "FindNetworkByRecordID", err)
+		err = helper.TriggeredError(client.Triggers, "FindNetworkByRecordID", err)
+		result, err = helper.TableResult(client.ResponseTables, "FindNetworkByRecordID", result, err, recordKeys)
+		result, err = helper.QueuedResult(client.Responses, "FindNetworkByRecordID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "FindNetworkByRecordID", result, err, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
+		if client.FindNetworkByRecordIDFunc != nil {
+			result, err = client.FindNetworkByRecordIDFunc(ctx, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"recordKeys": recordKeys,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8014, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8014, err, details)
+		})
 	}
+	client.record("FindNetworkByRecordID", flags, result, err, recordKeys, maxDegrees, buildOutDegree, buildOutMaxEntities, flags)
 	return result, err
 }
 
@@ -518,24 +866,39 @@ Output
 func (client *Szengine) FindPathByEntityID(ctx context.Context, startEntityID int64, endEntityID int64, maxDegrees int64, avoidEntityIDs string, requiredDataSources string, flags int64) (string, error) {
 	var err error
 	result := client.FindPathByEntityIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(31, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags)
 		defer func() {
 			client.traceExit(32, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "FindPathByEntityID", err)
+	if err == nil {
+		err = client.FindPathByEntityIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "FindPathByEntityID", err)
+		err = helper.TriggeredError(client.Triggers, "FindPathByEntityID", err)
+		result, err = helper.TableResult(client.ResponseTables, "FindPathByEntityID", result, err, startEntityID, endEntityID)
+		result, err = helper.QueuedResult(client.Responses, "FindPathByEntityID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "FindPathByEntityID", result, err, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags)
+		if client.FindPathByEntityIDFunc != nil {
+			result, err = client.FindPathByEntityIDFunc(ctx, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"startEntityID":       formatEntityID(startEntityID),
 				"endEntityID":         formatEntityID(endEntityID),
 				"avoidEntityIDs":      avoidEntityIDs,
 				"requiredDataSources": requiredDataSources,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8015, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8015, err, details)
+		})
 	}
+	client.record("FindPathByEntityID", flags, result, err, startEntityID, endEntityID, maxDegrees, avoidEntityIDs, requiredDataSources, flags)
 	return result, err
 }
 
@@ -563,15 +926,29 @@ Output
 func (client *Szengine) FindPathByRecordID(ctx context.Context, startDataSourceCode string, startRecordID string, endDataSourceCode string, endRecordID string, maxDegrees int64, avoidRecordKeys string, requiredDataSources string, flags int64) (string, error) {
 	var err error
 	result := client.FindPathByRecordIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(33, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags)
 		defer func() {
 			client.traceExit(34, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "FindPathByRecordID", err)
+	if err == nil {
+		err = client.FindPathByRecordIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "FindPathByRecordID", err)
+		err = helper.TriggeredError(client.Triggers, "FindPathByRecordID", err)
+		result, err = helper.TableResult(client.ResponseTables, "FindPathByRecordID", result, err, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID)
+		result, err = helper.QueuedResult(client.Responses, "FindPathByRecordID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "FindPathByRecordID", result, err, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags)
+		if client.FindPathByRecordIDFunc != nil {
+			result, err = client.FindPathByRecordIDFunc(ctx, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"startDataSourceCode": startDataSourceCode,
 				"startRecordID":       startRecordID,
@@ -580,9 +957,10 @@ func (client *Szengine) FindPathByRecordID(ctx context.Context, startDataSourceC
 				"avoidRecordKeys":     avoidRecordKeys,
 				"requiredDataSources": requiredDataSources,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8016, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8016, err, details)
+		})
 	}
+	client.record("FindPathByRecordID", flags, result, err, startDataSourceCode, startRecordID, endDataSourceCode, endRecordID, maxDegrees, avoidRecordKeys, requiredDataSources, flags)
 	return result, err
 }
 
@@ -598,17 +976,31 @@ Output
 func (client *Szengine) GetActiveConfigID(ctx context.Context) (int64, error) {
 	var err error
 	result := client.GetActiveConfigIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(35)
 		defer func() { client.traceExit(36, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetActiveConfigID", err)
+	if err == nil {
+		err = client.GetActiveConfigIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetActiveConfigID", err)
+		err = helper.TriggeredError(client.Triggers, "GetActiveConfigID", err)
+		result, err = helper.QueuedResult(client.Responses, "GetActiveConfigID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetActiveConfigID", result, err)
+		if client.GetActiveConfigIDFunc != nil {
+			result, err = client.GetActiveConfigIDFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8017, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8017, err, details)
+		})
 	}
+	client.record("GetActiveConfigID", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -628,19 +1020,37 @@ Output
 func (client *Szengine) GetEntityByEntityID(ctx context.Context, entityID int64, flags int64) (string, error) {
 	var err error
 	result := client.GetEntityByEntityIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(37, entityID, flags)
 		defer func() { client.traceExit(38, entityID, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetEntityByEntityID", err)
+	if err == nil {
+		err = client.GetEntityByEntityIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetEntityByEntityID", err)
+		err = helper.TriggeredError(client.Triggers, "GetEntityByEntityID", err)
+		if err == nil && client.Repository != nil {
+			result, err = client.Repository.getEntityByEntityID(entityID)
+		}
+		result, err = helper.TableResult(client.ResponseTables, "GetEntityByEntityID", result, err, entityID)
+		result, err = helper.QueuedResult(client.Responses, "GetEntityByEntityID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetEntityByEntityID", result, err, entityID, flags)
+		if client.GetEntityByEntityIDFunc != nil {
+			result, err = client.GetEntityByEntityIDFunc(ctx, entityID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8018, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8018, err, details)
+		})
 	}
+	client.record("GetEntityByEntityID", flags, result, err, entityID, flags)
 	return result, err
 }
 
@@ -660,22 +1070,40 @@ Output
 func (client *Szengine) GetEntityByRecordID(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
 	var err error
 	result := client.GetEntityByRecordIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(39, dataSourceCode, recordID, flags)
 		defer func() {
 			client.traceExit(40, dataSourceCode, recordID, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetEntityByRecordID", err)
+	if err == nil {
+		err = client.GetEntityByRecordIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetEntityByRecordID", err)
+		err = helper.TriggeredError(client.Triggers, "GetEntityByRecordID", err)
+		if err == nil && client.Repository != nil {
+			result, err = client.Repository.getEntityByRecordID(dataSourceCode, recordID)
+		}
+		result, err = helper.TableResult(client.ResponseTables, "GetEntityByRecordID", result, err, dataSourceCode, recordID)
+		result, err = helper.QueuedResult(client.Responses, "GetEntityByRecordID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetEntityByRecordID", result, err, dataSourceCode, recordID, flags)
+		if client.GetEntityByRecordIDFunc != nil {
+			result, err = client.GetEntityByRecordIDFunc(ctx, dataSourceCode, recordID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8019, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8019, err, details)
+		})
 	}
+	client.record("GetEntityByRecordID", flags, result, err, dataSourceCode, recordID, flags)
 	return result, err
 }
 
@@ -695,22 +1123,40 @@ Output
 func (client *Szengine) GetRecord(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
 	var err error
 	result := client.GetRecordResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(45, dataSourceCode, recordID, flags)
 		defer func() {
 			client.traceExit(46, dataSourceCode, recordID, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetRecord", err)
+	if err == nil {
+		err = client.GetRecordError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetRecord", err)
+		err = helper.TriggeredError(client.Triggers, "GetRecord", err)
+		if err == nil && client.Repository != nil {
+			result, err = client.Repository.getRecord(dataSourceCode, recordID)
+		}
+		result, err = helper.TableResult(client.ResponseTables, "GetRecord", result, err, dataSourceCode, recordID)
+		result, err = helper.QueuedResult(client.Responses, "GetRecord", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetRecord", result, err, dataSourceCode, recordID, flags)
+		if client.GetRecordFunc != nil {
+			result, err = client.GetRecordFunc(ctx, dataSourceCode, recordID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8020, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8020, err, details)
+		})
 	}
+	client.record("GetRecord", flags, result, err, dataSourceCode, recordID, flags)
 	return result, err
 }
 
@@ -728,17 +1174,34 @@ Output
 func (client *Szengine) GetRedoRecord(ctx context.Context) (string, error) {
 	var err error
 	result := client.GetRedoRecordResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(47)
 		defer func() { client.traceExit(48, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetRedoRecord", err)
+	if err == nil {
+		err = client.GetRedoRecordError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetRedoRecord", err)
+		err = helper.TriggeredError(client.Triggers, "GetRedoRecord", err)
+		result, err = helper.QueuedResult(client.Responses, "GetRedoRecord", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetRedoRecord", result, err)
+		if client.GetRedoRecordFunc != nil {
+			result, err = client.GetRedoRecordFunc(ctx)
+		}
+		if err == nil && client.RedoQueue != nil && client.GetRedoRecordFunc == nil && result == client.GetRedoRecordResult {
+			result = client.RedoQueue.pop()
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8021, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8021, err, details)
+		})
 	}
+	client.record("GetRedoRecord", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -756,17 +1219,31 @@ Output
 func (client *Szengine) GetStats(ctx context.Context) (string, error) {
 	var err error
 	result := client.GetStatsResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(49)
 		defer func() { client.traceExit(50, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetStats", err)
+	if err == nil {
+		err = client.GetStatsError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetStats", err)
+		err = helper.TriggeredError(client.Triggers, "GetStats", err)
+		result, err = helper.QueuedResult(client.Responses, "GetStats", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetStats", result, err)
+		if client.GetStatsFunc != nil {
+			result, err = client.GetStatsFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8022, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8022, err, details)
+		})
 	}
+	client.record("GetStats", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -787,18 +1264,36 @@ Output
 func (client *Szengine) GetVirtualEntityByRecordID(ctx context.Context, recordKeys string, flags int64) (string, error) {
 	var err error
 	result := client.GetVirtualEntityByRecordIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(51, recordKeys, flags)
 		defer func() { client.traceExit(52, recordKeys, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetVirtualEntityByRecordID", err)
+	if err == nil {
+		err = client.GetVirtualEntityByRecordIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetVirtualEntityByRecordID", err)
+		err = helper.TriggeredError(client.Triggers, "GetVirtualEntityByRecordID", err)
+		if err == nil && client.Repository != nil {
+			result, err = client.Repository.getVirtualEntityByRecordID(recordKeys)
+		}
+		result, err = helper.TableResult(client.ResponseTables, "GetVirtualEntityByRecordID", result, err, recordKeys)
+		result, err = helper.QueuedResult(client.Responses, "GetVirtualEntityByRecordID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetVirtualEntityByRecordID", result, err, recordKeys, flags)
+		if client.GetVirtualEntityByRecordIDFunc != nil {
+			result, err = client.GetVirtualEntityByRecordIDFunc(ctx, recordKeys, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"recordKeys": recordKeys}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8023, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8023, err, details)
+		})
 	}
+	client.record("GetVirtualEntityByRecordID", flags, result, err, recordKeys, flags)
 	return result, err
 }
 
@@ -818,19 +1313,34 @@ Output
 func (client *Szengine) HowEntityByEntityID(ctx context.Context, entityID int64, flags int64) (string, error) {
 	var err error
 	result := client.HowEntityByEntityIDResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(53, entityID, flags)
 		defer func() { client.traceExit(54, entityID, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "HowEntityByEntityID", err)
+	if err == nil {
+		err = client.HowEntityByEntityIDError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "HowEntityByEntityID", err)
+		err = helper.TriggeredError(client.Triggers, "HowEntityByEntityID", err)
+		result, err = helper.TableResult(client.ResponseTables, "HowEntityByEntityID", result, err, entityID)
+		result, err = helper.QueuedResult(client.Responses, "HowEntityByEntityID", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "HowEntityByEntityID", result, err, entityID, flags)
+		if client.HowEntityByEntityIDFunc != nil {
+			result, err = client.HowEntityByEntityIDFunc(ctx, entityID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8024, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8024, err, details)
+		})
 	}
+	client.record("HowEntityByEntityID", flags, result, err, entityID, flags)
 	return result, err
 }
 
@@ -844,17 +1354,31 @@ Input
 */
 func (client *Szengine) PrimeEngine(ctx context.Context) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(57)
 		defer func() { client.traceExit(58, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "PrimeEngine", err)
+	if err == nil {
+		err = client.PrimeEngineError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "PrimeEngine", err)
+		err = helper.TriggeredError(client.Triggers, "PrimeEngine", err)
+		err = helper.QueuedError(client.Responses, "PrimeEngine", err)
+		err = helper.ExpectedError(client.Expectations, "PrimeEngine", err)
+		if client.PrimeEngineFunc != nil {
+			err = client.PrimeEngineFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8026, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8026, err, details)
+		})
 	}
+	client.record("PrimeEngine", senzing.SzNoFlags, nil, err)
 	return err
 }
 
@@ -871,17 +1395,51 @@ Output
 func (client *Szengine) ProcessRedoRecord(ctx context.Context, redoRecord string, flags int64) (string, error) {
 	var err error
 	result := client.ProcessRedoRecordResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(59, redoRecord, flags)
 		defer func() { client.traceExit(60, redoRecord, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ProcessRedoRecord", err)
+	if err == nil {
+		err = client.ProcessRedoRecordError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ProcessRedoRecord", err)
+		err = helper.TriggeredError(client.Triggers, "ProcessRedoRecord", err)
+		isValid := false
+		if err == nil && client.Repository != nil {
+			if _, _, parseErr := parseRedoRecord(redoRecord); parseErr != nil {
+				err = helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, fmt.Sprintf("invalid redo record: %v", parseErr))
+			}
+			isValid = err == nil
+		}
+		result, err = helper.QueuedResult(client.Responses, "ProcessRedoRecord", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ProcessRedoRecord", result, err, redoRecord, flags)
+		if client.ProcessRedoRecordFunc != nil {
+			result, err = client.ProcessRedoRecordFunc(ctx, redoRecord, flags)
+		}
+		if err == nil {
+			dataSourceCode, recordID, _ := parseRedoRecord(redoRecord)
+			affectedEntityIDs := []int64{}
+			if isValid {
+				affectedEntityIDs = client.Repository.processRedoRecord(dataSourceCode, recordID)
+			}
+			if client.RedoQueue != nil {
+				client.RedoQueue.process(redoRecord)
+			}
+			if client.GenerateWithInfo && client.ProcessRedoRecordFunc == nil && result == client.ProcessRedoRecordResult {
+				result, err = buildWithInfo(dataSourceCode, recordID, affectedEntityIDs, flags)
+			}
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8027, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8027, err, details)
+		})
 	}
+	client.record("ProcessRedoRecord", flags, result, err, redoRecord, flags)
 	return result, err
 }
 
@@ -897,19 +1455,41 @@ Input
 func (client *Szengine) ReevaluateEntity(ctx context.Context, entityID int64, flags int64) (string, error) {
 	var err error
 	result := client.ReevaluateEntityResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(61, entityID, flags)
 		defer func() { client.traceExit(62, entityID, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ReevaluateEntity", err)
+	if err == nil {
+		err = client.ReevaluateEntityError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ReevaluateEntity", err)
+		err = helper.TriggeredError(client.Triggers, "ReevaluateEntity", err)
+		key := recordKey{}
+		affectedEntityIDs := []int64{entityID}
+		if err == nil && client.Repository != nil {
+			key, affectedEntityIDs, err = client.Repository.reevaluateEntity(entityID)
+		}
+		if err == nil && client.GenerateWithInfo {
+			result, err = buildWithInfo(key.dataSourceCode, key.recordID, affectedEntityIDs, flags)
+		}
+		result, err = helper.QueuedResult(client.Responses, "ReevaluateEntity", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ReevaluateEntity", result, err, entityID, flags)
+		if client.ReevaluateEntityFunc != nil {
+			result, err = client.ReevaluateEntityFunc(ctx, entityID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"entityID": formatEntityID(entityID),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8028, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8028, err, details)
+		})
 	}
+	client.record("ReevaluateEntity", flags, result, err, entityID, flags)
 	return result, err
 }
 
@@ -926,20 +1506,41 @@ Input
 func (client *Szengine) ReevaluateRecord(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
 	var err error
 	result := client.ReevaluateRecordResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(63, dataSourceCode, recordID, flags)
 		defer func() { client.traceExit(64, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "ReevaluateRecord", err)
+	if err == nil {
+		err = client.ReevaluateRecordError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "ReevaluateRecord", err)
+		err = helper.TriggeredError(client.Triggers, "ReevaluateRecord", err)
+		affectedEntityIDs := []int64{}
+		if err == nil && client.Repository != nil {
+			affectedEntityIDs, err = client.Repository.reevaluateRecord(dataSourceCode, recordID)
+		}
+		if err == nil && client.GenerateWithInfo {
+			result, err = buildWithInfo(dataSourceCode, recordID, affectedEntityIDs, flags)
+		}
+		result, err = helper.QueuedResult(client.Responses, "ReevaluateRecord", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "ReevaluateRecord", result, err, dataSourceCode, recordID, flags)
+		if client.ReevaluateRecordFunc != nil {
+			result, err = client.ReevaluateRecordFunc(ctx, dataSourceCode, recordID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8029, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8029, err, details)
+		})
 	}
+	client.record("ReevaluateRecord", flags, result, err, dataSourceCode, recordID, flags)
 	return result, err
 }
 
@@ -952,19 +1553,33 @@ Input
 */
 func (client *Szengine) Reinitialize(ctx context.Context, configID int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(65, configID)
 		defer func() { client.traceExit(66, configID, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Reinitialize", err)
+	if err == nil {
+		err = client.ReinitializeError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Reinitialize", err)
+		err = helper.TriggeredError(client.Triggers, "Reinitialize", err)
+		err = helper.QueuedError(client.Responses, "Reinitialize", err)
+		err = helper.ExpectedError(client.Expectations, "Reinitialize", err, configID)
+		if client.ReinitializeFunc != nil {
+			err = client.ReinitializeFunc(ctx, configID)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"configID": strconv.FormatInt(configID, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8030, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8030, err, details)
+		})
 	}
+	client.record("Reinitialize", senzing.SzNoFlags, nil, err, configID)
 	return err
 }
 
@@ -985,20 +1600,35 @@ Output
 func (client *Szengine) SearchByAttributes(ctx context.Context, attributes string, searchProfile string, flags int64) (string, error) {
 	var err error
 	result := client.SearchByAttributesResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(69, attributes, searchProfile, flags)
 		defer func() { client.traceExit(70, attributes, searchProfile, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "SearchByAttributes", err)
+	if err == nil {
+		err = client.SearchByAttributesError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "SearchByAttributes", err)
+		err = helper.TriggeredError(client.Triggers, "SearchByAttributes", err)
+		result, err = helper.TableResult(client.ResponseTables, "SearchByAttributes", result, err, attributes)
+		result, err = helper.QueuedResult(client.Responses, "SearchByAttributes", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "SearchByAttributes", result, err, attributes, searchProfile, flags)
+		if client.SearchByAttributesFunc != nil {
+			result, err = client.SearchByAttributesFunc(ctx, attributes, searchProfile, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"attributes":    attributes,
 				"searchProfile": searchProfile,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8031, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8031, err, details)
+		})
 	}
+	client.record("SearchByAttributes", flags, result, err, attributes, searchProfile, flags)
 	return result, err
 }
 
@@ -1021,20 +1651,35 @@ Output
 func (client *Szengine) WhyEntities(ctx context.Context, entityID1 int64, entityID2 int64, flags int64) (string, error) {
 	var err error
 	result := client.WhyEntitiesResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(71, entityID1, entityID2, flags)
 		defer func() { client.traceExit(72, entityID1, entityID2, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "WhyEntities", err)
+	if err == nil {
+		err = client.WhyEntitiesError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "WhyEntities", err)
+		err = helper.TriggeredError(client.Triggers, "WhyEntities", err)
+		result, err = helper.TableResult(client.ResponseTables, "WhyEntities", result, err, entityID1, entityID2)
+		result, err = helper.QueuedResult(client.Responses, "WhyEntities", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "WhyEntities", result, err, entityID1, entityID2, flags)
+		if client.WhyEntitiesFunc != nil {
+			result, err = client.WhyEntitiesFunc(ctx, entityID1, entityID2, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"entityID1": formatEntityID(entityID1),
 				"entityID2": formatEntityID(entityID2),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8032, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8032, err, details)
+		})
 	}
+	client.record("WhyEntities", flags, result, err, entityID1, entityID2, flags)
 	return result, err
 }
 
@@ -1055,20 +1700,35 @@ Output
 func (client *Szengine) WhyRecordInEntity(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
 	var err error
 	result := client.WhyRecordInEntityResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(73, dataSourceCode, recordID, flags)
 		defer func() { client.traceExit(74, dataSourceCode, recordID, flags, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "WhyRecordInEntity", err)
+	if err == nil {
+		err = client.WhyRecordInEntityError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "WhyRecordInEntity", err)
+		err = helper.TriggeredError(client.Triggers, "WhyRecordInEntity", err)
+		result, err = helper.TableResult(client.ResponseTables, "WhyRecordInEntity", result, err, dataSourceCode, recordID)
+		result, err = helper.QueuedResult(client.Responses, "WhyRecordInEntity", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "WhyRecordInEntity", result, err, dataSourceCode, recordID, flags)
+		if client.WhyRecordInEntityFunc != nil {
+			result, err = client.WhyRecordInEntityFunc(ctx, dataSourceCode, recordID, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode": dataSourceCode,
 				"recordID":       recordID,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8033, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8033, err, details)
+		})
 	}
+	client.record("WhyRecordInEntity", flags, result, err, dataSourceCode, recordID, flags)
 	return result, err
 }
 
@@ -1090,24 +1750,39 @@ Output
 func (client *Szengine) WhyRecords(ctx context.Context, dataSourceCode1 string, recordID1 string, dataSourceCode2 string, recordID2 string, flags int64) (string, error) {
 	var err error
 	result := client.WhyRecordsResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(75, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
 		defer func() {
 			client.traceExit(76, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags, result, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "WhyRecords", err)
+	if err == nil {
+		err = client.WhyRecordsError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "WhyRecords", err)
+		err = helper.TriggeredError(client.Triggers, "WhyRecords", err)
+		result, err = helper.TableResult(client.ResponseTables, "WhyRecords", result, err, dataSourceCode1, recordID1, dataSourceCode2, recordID2)
+		result, err = helper.QueuedResult(client.Responses, "WhyRecords", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "WhyRecords", result, err, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
+		if client.WhyRecordsFunc != nil {
+			result, err = client.WhyRecordsFunc(ctx, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"dataSourceCode1": dataSourceCode1,
 				"recordID1":       recordID1,
 				"dataSourceCode2": dataSourceCode2,
 				"recordID2":       recordID2,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8034, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8034, err, details)
+		})
 	}
+	client.record("WhyRecords", flags, result, err, dataSourceCode1, recordID1, dataSourceCode2, recordID2, flags)
 	return result, err
 }
 
@@ -1115,6 +1790,34 @@ func (client *Szengine) WhyRecords(ctx context.Context, dataSourceCode1 string,
 // Public non-interface methods
 // ----------------------------------------------------------------------------
 
+/*
+The FlushNotifications method waits until observers have received the notifications
+sent asynchronously by the Szengine object.
+Unnecessary if SynchronousNotifications is set.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - ctx.Err() if ctx was cancelled or expired before the notifications were delivered.
+*/
+func (client *Szengine) FlushNotifications(ctx context.Context) error {
+	return client.notifications.Wait(ctx)
+}
+
+/*
+The GetCallRecorder method returns the log of calls made to the Szengine object.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The CallRecorder holding the calls made to the Szengine object.
+*/
+func (client *Szengine) GetCallRecorder(ctx context.Context) *helper.CallRecorder {
+	return &client.calls
+}
+
 /*
 The GetObserverOrigin method returns the "origin" value of past Observer messages.
 
@@ -1126,6 +1829,8 @@ Output
 */
 func (client *Szengine) GetObserverOrigin(ctx context.Context) string {
 	_ = ctx
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
 	return client.observerOrigin
 }
 
@@ -1142,24 +1847,38 @@ Input
 */
 func (client *Szengine) Initialize(ctx context.Context, instanceName string, settings string, configID int64, verboseLogging int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(55, instanceName, settings, configID, verboseLogging)
 		defer func() {
 			client.traceExit(56, instanceName, settings, configID, verboseLogging, err, time.Since(entryTime))
 		}()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Initialize", err)
+	if err == nil {
+		err = client.InitializeError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Initialize", err)
+		err = helper.TriggeredError(client.Triggers, "Initialize", err)
+		err = helper.QueuedError(client.Responses, "Initialize", err)
+		err = helper.ExpectedError(client.Expectations, "Initialize", err, instanceName, settings, configID, verboseLogging)
+		if client.InitializeFunc != nil {
+			err = client.InitializeFunc(ctx, instanceName, settings, configID, verboseLogging)
+		}
+		err = client.lifecycle.Initialize(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"configID":       strconv.FormatInt(configID, baseTen),
 				"instanceName":   instanceName,
 				"settings":       settings,
 				"verboseLogging": strconv.FormatInt(verboseLogging, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8025, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8025, err, details)
+		})
 	}
+	client.record("Initialize", senzing.SzNoFlags, nil, err, instanceName, settings, configID, verboseLogging)
 	return err
 }
 
@@ -1172,22 +1891,24 @@ Input
 */
 func (client *Szengine) RegisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(703, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
+	client.mutex.Lock()
 	if client.observers == nil {
-		client.observers = &subject.SimpleSubject{}
+		client.observers = &helper.SynchronousSubject{}
 	}
 	err = client.observers.RegisterObserver(ctx, observer)
-	if client.observers != nil {
-		go func() {
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8702, err, details)
+		})
 	}
 	return err
 }
@@ -1201,7 +1922,7 @@ Input
 */
 func (client *Szengine) SetLogLevel(ctx context.Context, logLevelName string) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(705, logLevelName)
 		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
@@ -1209,15 +1930,17 @@ func (client *Szengine) SetLogLevel(ctx context.Context, logLevelName string) er
 	if !logging.IsValidLogLevelName(logLevelName) {
 		return fmt.Errorf("invalid error level: %s", logLevelName)
 	}
+	client.mutex.Lock()
 	err = client.getLogger().SetLogLevel(logLevelName)
-	client.isTrace = (logLevelName == logging.LevelTraceName)
-	if client.observers != nil {
-		go func() {
+	client.isTrace.Store(logLevelName == logging.LevelTraceName)
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8703, err, details)
+		})
 	}
 	return err
 }
@@ -1231,6 +1954,8 @@ Input
 */
 func (client *Szengine) SetObserverOrigin(ctx context.Context, origin string) {
 	_ = ctx
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.observerOrigin = origin
 }
 
@@ -1243,23 +1968,26 @@ Input
 */
 func (client *Szengine) UnregisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(707, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
+	if observers, observerOrigin := client.getObservers(); observers != nil {
 		// Tricky code:
-		// client.notify is called synchronously before client.observers is set to nil.
-		// In client.notify, each observer will get notified in a goroutine.
-		// Then client.observers may be set to nil, but observer goroutines will be OK.
+		// The observer being removed is notified, before it is removed, in the calling goroutine.
+		// client.mutex is not held, so observers may call the Szengine object.
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
-		err = client.observers.UnregisterObserver(ctx, observer)
-		if !client.observers.HasObservers(ctx) {
-			client.observers = nil
+		notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8704, err, details)
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		if client.observers != nil {
+			err = client.observers.UnregisterObserver(ctx, observer)
+			if !client.observers.HasObservers(ctx) {
+				client.observers = nil
+			}
 		}
 	}
 	return err
@@ -1271,7 +1999,7 @@ func (client *Szengine) UnregisterObserver(ctx context.Context, observer observe
 
 // --- Logging ----------------------------------------------------------------
 
-// Get the Logger singleton.
+// Get the Logger singleton.  The caller holds client.mutex.
 func (client *Szengine) getLogger() logging.Logging {
 	if client.logger == nil {
 		client.logger = helper.GetLogger(ComponentID, szengine.IDMessages, baseCallerSkip)
@@ -1279,16 +2007,79 @@ func (client *Szengine) getLogger() logging.Logging {
 	return client.logger
 }
 
+// Get the observers and the origin of their messages.
+func (client *Szengine) getObservers() (subject.Subject, string) {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.observers, client.observerOrigin
+}
+
 // Trace method entry.
 func (client *Szengine) traceEntry(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
 
 // Trace method exit.
 func (client *Szengine) traceExit(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
 
+// ----------------------------------------------------------------------------
+// Export iterators
+// ----------------------------------------------------------------------------
+
+/*
+Send fragments to an iterator's channel, followed by err if it is not nil, then close the channel.
+When ctx is done, sending stops with a fragment holding ctx.Err() or err.  That fragment replaces
+any fragment still buffered, so the goroutine never blocks on a receiver that stopped reading.
+*/
+func sendFragments(ctx context.Context, stringFragmentChannel chan senzing.StringFragment, fragments []string, err error) {
+	defer close(stringFragmentChannel)
+	for _, fragment := range fragments {
+		select {
+		case <-ctx.Done():
+			replaceFragment(stringFragmentChannel, ctx.Err())
+			return
+		case stringFragmentChannel <- senzing.StringFragment{Value: fragment}:
+		}
+	}
+	if err != nil {
+		select {
+		case <-ctx.Done():
+			replaceFragment(stringFragmentChannel, err)
+		case stringFragmentChannel <- senzing.StringFragment{Error: err}:
+		}
+	}
+}
+
+// Put an error fragment in a channel buffering one fragment, dropping the buffered fragment.  The caller is the only sender.
+func replaceFragment(stringFragmentChannel chan senzing.StringFragment, err error) {
+	select {
+	case <-stringFragmentChannel:
+	default:
+	}
+	stringFragmentChannel <- senzing.StringFragment{Error: err}
+}
+
+// ----------------------------------------------------------------------------
+// Call recording
+// ----------------------------------------------------------------------------
+
+// Record the call in the call log.
+func (client *Szengine) record(method string, flags int64, result interface{}, err error, arguments ...interface{}) {
+	client.calls.Record(helper.Call{
+		Arguments: arguments,
+		Error:     err,
+		Flags:     flags,
+		Method:    method,
+		Result:    result,
+	})
+}
+
 func formatEntityID(entityID int64) string {
 	return strconv.FormatInt(entityID, baseTen)
 }
@@ -3,16 +3,24 @@ package szengine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-helpers/record"
 	"github.com/senzing-garage/go-helpers/truthset"
+	"github.com/senzing-garage/go-logging/logging"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/helper/helpertest"
+	"github.com/senzing-garage/sz-sdk-go-mock/szconfig"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,6 +53,38 @@ type GetEntityByRecordIDResponse struct {
 	} `json:"RESOLVED_ENTITY"`
 }
 
+// An observer that collects its messages.
+type recordingObserver struct {
+	id       string
+	messages []string
+	mutex    sync.Mutex
+}
+
+func (observer *recordingObserver) GetObserverID(ctx context.Context) string {
+	_ = ctx
+	return observer.id
+}
+
+func (observer *recordingObserver) UpdateObserver(ctx context.Context, message string) {
+	_ = ctx
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	observer.messages = append(observer.messages, message)
+}
+
+// Get the "messageId" of each message received.
+func (observer *recordingObserver) getMessageIDs(test *testing.T) []string {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	result := []string{}
+	for _, message := range observer.messages {
+		details := map[string]string{}
+		require.NoError(test, json.Unmarshal([]byte(message), &details))
+		result = append(result, details["messageId"])
+	}
+	return result
+}
+
 var (
 	defaultConfigID int64
 	logLevel        = helper.GetEnv("SENZING_LOG_LEVEL", "INFO")
@@ -100,6 +140,242 @@ func TestSzengine_AddRecord_withInfo(test *testing.T) {
 	}
 }
 
+func TestSzengine_AddRecord_func(test *testing.T) {
+	ctx := context.TODO()
+	added := map[string]string{}
+	szEngine := &Szengine{
+		AddRecordResult: "static",
+		AddRecordFunc: func(ctx context.Context, dataSourceCode string, recordID string, recordDefinition string, flags int64) (string, error) {
+			_ = ctx
+			_ = flags
+			if _, ok := added[dataSourceCode+recordID]; ok {
+				return "", helper.NewSzErrorFromCatalog(helper.SzErrorBadInput, "duplicate record")
+			}
+			added[dataSourceCode+recordID] = recordDefinition
+			return fmt.Sprintf(`{"DATA_SOURCE":%q,"RECORD_ID":%q}`, dataSourceCode, recordID), nil
+		},
+	}
+	err := szEngine.SetLogLevel(ctx, logging.LevelTraceName)
+	require.NoError(test, err)
+	err = szEngine.RegisterObserver(ctx, observerSingleton)
+	require.NoError(test, err)
+	record := truthset.CustomerRecords["1001"]
+	actual, err := szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.Equal(test, fmt.Sprintf(`{"DATA_SOURCE":%q,"RECORD_ID":%q}`, record.DataSource, record.ID), actual)
+	assert.Equal(test, record.JSON, added[record.DataSource+record.ID])
+	_, err = szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithInfo)
+	require.ErrorIs(test, err, szerror.ErrSzBadInput)
+	assert.Equal(test, 2, szEngine.GetCallRecorder(ctx).Count("AddRecord"))
+}
+
+func TestSzengine_AddRecord_honorContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	szEngine := &Szengine{
+		HonorContext: true,
+		Repository:   NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	cancel()
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1002", `{}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.Canceled)
+	assert.Equal(test, 1, szEngine.Repository.RecordCount())
+	fragments := []senzing.StringFragment{}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		fragments = append(fragments, fragment)
+	}
+	require.Len(test, fragments, 1)
+	require.ErrorIs(test, fragments[0].Error, context.Canceled)
+	szEngine.HonorContext = false
+	_, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1002", `{}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+}
+
+func TestSzengine_GetStats_honorContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("GetStats", helper.Response{Result: "{}"})
+	szEngine := &Szengine{
+		GetStatsFunc: func(ctx context.Context) (string, error) {
+			return "{}", nil
+		},
+		HonorContext: true,
+		Responses:    responses,
+	}
+	_, err := szEngine.GetStats(ctx)
+	require.ErrorIs(test, err, context.Canceled)
+	assert.Equal(test, 1, responses.Len("GetStats"))
+}
+
+func TestSzengine_AddRecord_chaos(test *testing.T) {
+	ctx := context.TODO()
+	chaos := helper.NewChaos(1, helper.ChaosSettings{})
+	chaos.Set("szengine", "AddRecord", helper.ChaosSettings{FailureRate: 1})
+	szEngine := &Szengine{
+		Chaos:      chaos,
+		Repository: NewRepository("CUSTOMERS"),
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	assert.Equal(test, 0, szEngine.Repository.RecordCount())
+	_, err = szEngine.GetRecord(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+	report := chaos.Report()
+	assert.Equal(test, map[string]int{helper.SzErrorDatabaseConnectionLost: 1}, report["szengine.AddRecord"].Faults)
+	assert.Equal(test, 1, report["szengine.GetRecord"].Calls)
+	assert.Empty(test, report["szengine.GetRecord"].Faults)
+}
+
+func TestSzengine_AddRecord_triggers(test *testing.T) {
+	ctx := context.TODO()
+	triggers := helper.NewTriggers()
+	triggers.Add("AddRecord", helper.OnCall(3), helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost))
+	szEngine := &Szengine{
+		Repository: NewRepository("CUSTOMERS"),
+		Triggers:   triggers,
+	}
+	for _, recordID := range []string{"1001", "1002", "1003", "1004"} {
+		_, err := szEngine.AddRecord(ctx, "CUSTOMERS", recordID, `{}`, senzing.SzNoFlags)
+		if recordID == "1003" {
+			require.ErrorIs(test, err, szerror.ErrSzRetryable)
+		} else {
+			require.NoError(test, err)
+		}
+	}
+	assert.Equal(test, 3, szEngine.Repository.RecordCount())
+}
+
+func TestSzengine_GetStats_strictLifecycleQueued(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("GetStats", helper.Response{Result: "{}"})
+	szEngine := &Szengine{
+		GetStatsFunc: func(ctx context.Context) (string, error) {
+			return "{}", nil
+		},
+		Responses:       responses,
+		StrictLifecycle: true,
+	}
+	_, err := szEngine.GetStats(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+	assert.Equal(test, 1, responses.Len("GetStats"))
+}
+
+func TestSzengine_GetStats_strictLifecycle(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		Repository:      NewRepository("CUSTOMERS"),
+		StrictLifecycle: true,
+	}
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+	assert.Equal(test, 0, szEngine.Repository.RecordCount())
+	require.NoError(test, szEngine.Initialize(ctx, "mock", "{}", senzing.SzInitializeWithDefaultConfiguration, senzing.SzNoLogging))
+	require.ErrorIs(test, szEngine.Initialize(ctx, "mock", "{}", senzing.SzInitializeWithDefaultConfiguration, senzing.SzNoLogging), szerror.ErrSzBadInput)
+	_, err = szEngine.GetStats(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szEngine.Destroy(ctx))
+	_, err = szEngine.GetStats(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+	fragments := []senzing.StringFragment{}
+	for fragment := range szEngine.ExportJSONEntityReportIterator(ctx, senzing.SzNoFlags) {
+		fragments = append(fragments, fragment)
+	}
+	require.Len(test, fragments, 1)
+	require.ErrorIs(test, fragments[0].Error, szerror.ErrSzNotInitialized)
+	require.ErrorIs(test, szEngine.Destroy(ctx), szerror.ErrSzNotInitialized)
+}
+
+func TestSzengine_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		GenerateWithInfo: true,
+		Repository:       NewRepository("CUSTOMERS"),
+	}
+	helpertest.RunConcurrently(ctx, test, szEngine, 8, 50, func(worker int, iteration int) error {
+		recordID := fmt.Sprintf("%d", worker)
+		_, err := szEngine.AddRecord(ctx, "CUSTOMERS", recordID, `{}`, senzing.SzWithInfo)
+		if err != nil {
+			return err
+		}
+		_, err = szEngine.GetRecord(ctx, "CUSTOMERS", recordID, senzing.SzNoFlags)
+		return err
+	})
+	assert.Equal(test, 8*50, szEngine.GetCallRecorder(ctx).Count("AddRecord"))
+	assert.Equal(test, 8, szEngine.Repository.RecordCount())
+}
+
+func TestSzengine_AddRecord_synchronousNotifications(test *testing.T) {
+	ctx := context.TODO()
+	recorder := &recordingObserver{id: "Observer 1"}
+	szEngine := &Szengine{
+		SynchronousNotifications: true,
+	}
+	require.NoError(test, szEngine.RegisterObserver(ctx, recorder))
+	_, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"8702", "8001"}, recorder.getMessageIDs(test))
+	require.NoError(test, szEngine.UnregisterObserver(ctx, recorder))
+	assert.Equal(test, []string{"8702", "8001", "8704"}, recorder.getMessageIDs(test))
+}
+
+func TestSzengine_FlushNotifications(test *testing.T) {
+	ctx := context.TODO()
+	recorder := &recordingObserver{id: "Observer 1"}
+	szEngine := &Szengine{}
+	require.NoError(test, szEngine.RegisterObserver(ctx, recorder))
+	for _, recordID := range []string{"1001", "1002", "1003"} {
+		_, err := szEngine.AddRecord(ctx, "CUSTOMERS", recordID, `{}`, senzing.SzNoFlags)
+		require.NoError(test, err)
+	}
+	require.NoError(test, szEngine.FlushNotifications(ctx))
+	messageIDs := recorder.getMessageIDs(test)
+	sort.Strings(messageIDs)
+	assert.Equal(test, []string{"8001", "8001", "8001", "8702"}, messageIDs)
+}
+
+func TestSzengine_GetEntityByEntityID_honorContext(test *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	szEngine := &Szengine{
+		HonorContext: true,
+	}
+	_, err := szEngine.GetEntityByEntityID(ctx, 1, senzing.SzNoFlags)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+}
+
+func TestSzengine_AddRecord_error(test *testing.T) {
+	ctx := context.TODO()
+	expectedErr := errors.New("AddRecord failed")
+	szEngine := &Szengine{
+		AddRecordError:     expectedErr,
+		DeleteRecordResult: "{}",
+	}
+	err := szEngine.SetLogLevel(ctx, logging.LevelTraceName)
+	require.NoError(test, err)
+	err = szEngine.RegisterObserver(ctx, observerSingleton)
+	require.NoError(test, err)
+	record := truthset.CustomerRecords["1001"]
+	_, err = szEngine.AddRecord(ctx, record.DataSource, record.ID, record.JSON, senzing.SzWithoutInfo)
+	require.ErrorIs(test, err, expectedErr)
+	actual, err := szEngine.DeleteRecord(ctx, record.DataSource, record.ID, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	assert.Equal(test, "{}", actual)
+}
+
+func TestSzengine_GetEntityByRecordID_error(test *testing.T) {
+	ctx := context.TODO()
+	record := truthset.CustomerRecords["1001"]
+	szEngine := &Szengine{
+		GetEntityByRecordIDError: helper.NewSzErrorFromCatalog(helper.SzErrorRecordNotFound, record.DataSource, record.ID),
+	}
+	_, err := szEngine.GetEntityByRecordID(ctx, record.DataSource, record.ID, senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
 func TestSzengine_CloseExport(test *testing.T) {
 	_ = test
 	// Tested in:
@@ -143,6 +419,21 @@ func TestSzengine_DeleteRecord_badRecordID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_DeleteRecord_expectations(test *testing.T) {
+	ctx := context.TODO()
+	expectations := helper.NewExpectations(test)
+	expectations.SetStrict(true)
+	expectations.Expect("DeleteRecord", "CUSTOMERS", helper.Any(), senzing.SzWithInfo).Times(2).Return(`{"AFFECTED_ENTITIES":[]}`, nil)
+	szEngine := &Szengine{
+		Expectations: expectations,
+	}
+	for _, recordID := range []string{"1001", "1002"} {
+		actual, err := szEngine.DeleteRecord(ctx, "CUSTOMERS", recordID, senzing.SzWithInfo)
+		require.NoError(test, err)
+		assert.Equal(test, `{"AFFECTED_ENTITIES":[]}`, actual)
+	}
+}
+
 func TestSzengine_DeleteRecord_withInfo(test *testing.T) {
 	ctx := context.TODO()
 	szEngine := getTestObject(ctx, test)
@@ -566,6 +857,25 @@ func TestSzengine_GetEntityByRecordID(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzengine_GetEntityByRecordID_responseTables(test *testing.T) {
+	ctx := context.TODO()
+	responseTables := &helper.ResponseTables{}
+	responseTables.Set("GetEntityByRecordID", helper.Response{Result: `{"RESOLVED_ENTITY":{"ENTITY_ID":1}}`}, "CUSTOMERS", "1001")
+	responseTables.Set("GetEntityByRecordID", helper.Response{Result: `{"RESOLVED_ENTITY":{"ENTITY_ID":2}}`}, "CUSTOMERS", "1002")
+	responseTables.SetNotFound("GetEntityByRecordID", helper.SzErrorRecordNotFound)
+	szEngine := &Szengine{
+		ResponseTables: responseTables,
+	}
+	actual, err := szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1001", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, `{"RESOLVED_ENTITY":{"ENTITY_ID":1}}`, actual)
+	actual, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1002", senzing.SzNoFlags)
+	require.NoError(test, err)
+	assert.Equal(test, `{"RESOLVED_ENTITY":{"ENTITY_ID":2}}`, actual)
+	_, err = szEngine.GetEntityByRecordID(ctx, "CUSTOMERS", "1003", senzing.SzNoFlags)
+	require.ErrorIs(test, err, szerror.ErrSzNotFound)
+}
+
 func TestSzengine_GetRecord(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -662,6 +972,32 @@ func TestSzengine_ProcessRedoRecord(test *testing.T) {
 	}
 }
 
+func TestSzengine_ProcessRedoRecord_responses(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("CountRedoRecords", helper.Response{Result: int64(2)})
+	responses.Enqueue("GetRedoRecord", helper.Response{Result: "redo-1"}, helper.Response{Result: "redo-2"})
+	responses.SetFallback("GetRedoRecord", helper.QueueFallbackZero)
+	szEngine := &Szengine{
+		Responses: responses,
+	}
+	count, err := szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), count)
+	processed := []string{}
+	for {
+		redoRecord, err := szEngine.GetRedoRecord(ctx)
+		require.NoError(test, err)
+		if len(redoRecord) == 0 {
+			break
+		}
+		_, err = szEngine.ProcessRedoRecord(ctx, redoRecord, senzing.SzNoFlags)
+		require.NoError(test, err)
+		processed = append(processed, redoRecord)
+	}
+	assert.Equal(test, []string{"redo-1", "redo-2"}, processed)
+}
+
 func TestSzengine_ProcessRedoRecord_withInfo(test *testing.T) {
 	ctx := context.TODO()
 	records := []record.Record{
@@ -946,6 +1282,53 @@ func TestSzengine_SetObserverOrigin(test *testing.T) {
 	szEngine.SetObserverOrigin(ctx, origin)
 }
 
+func TestSzengine_GetCallRecorder(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{}
+	record1 := truthset.CustomerRecords["1001"]
+	record2 := truthset.CustomerRecords["1002"]
+	_, err := szEngine.AddRecord(ctx, record1.DataSource, record1.ID, record1.JSON, senzing.SzWithInfo)
+	require.NoError(test, err)
+	_, err = szEngine.AddRecord(ctx, record2.DataSource, record2.ID, record2.JSON, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	_, err = szEngine.CountRedoRecords(ctx)
+	require.NoError(test, err)
+	callRecorder := szEngine.GetCallRecorder(ctx)
+	assert.Len(test, callRecorder.Calls(), 3)
+	assert.Equal(test, 2, callRecorder.Count("AddRecord"))
+	lastCall, ok := callRecorder.LastCall("AddRecord")
+	require.True(test, ok)
+	assert.Equal(test, []interface{}{record2.DataSource, record2.ID, record2.JSON, senzing.SzWithoutInfo}, lastCall.Arguments)
+	assert.Equal(test, senzing.SzWithoutInfo, lastCall.Flags)
+	assert.False(test, lastCall.Time.IsZero())
+	assert.Equal(test, senzing.SzWithInfo, callRecorder.CallsTo("AddRecord")[0].Flags)
+	callRecorder.Reset()
+	assert.Empty(test, callRecorder.Calls())
+	_, ok = callRecorder.LastCall("AddRecord")
+	assert.False(test, ok)
+}
+
+func TestSzengine_GetCallRecorder_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{}
+	callRecorder := szEngine.GetCallRecorder(ctx)
+	numGoroutines := 10
+	numCalls := 100
+	var waitGroup sync.WaitGroup
+	for i := 0; i < numGoroutines; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for j := 0; j < numCalls; j++ {
+				_, _ = szEngine.GetStats(ctx)
+				_ = callRecorder.Count("GetStats")
+			}
+		}()
+	}
+	waitGroup.Wait()
+	assert.Equal(test, numGoroutines*numCalls, callRecorder.Count("GetStats"))
+}
+
 func TestSzengine_GetObserverOrigin(test *testing.T) {
 	ctx := context.TODO()
 	szEngine := getTestObject(ctx, test)
@@ -1104,7 +1487,7 @@ func getSzEngine(ctx context.Context) (*Szengine, error) {
 			AddRecordResult:                         "{}",
 			CountRedoRecordsResult:                  int64(0),
 			DeleteRecordResult:                      "{}",
-			ExportConfigResult:                      `{"G2_CONFIG":{"CFG_ETYPE":[{"ETYPE_ID":...`,
+			ExportConfigResult:                      szconfig.ConfigTemplate,
 			ExportCsvEntityReportResult:             1,
 			ExportJSONEntityReportResult:            1,
 			FetchNextResult:                         ``,
@@ -0,0 +1,73 @@
+package szengine
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+type affectedEntity struct {
+	EntityID int64 `json:"ENTITY_ID"`
+}
+
+type interestingEntities struct {
+	Entities []interface{} `json:"ENTITIES"`
+}
+
+type withInfoResponse struct {
+	DataSource          string              `json:"DATA_SOURCE,omitempty"`
+	RecordID            string              `json:"RECORD_ID,omitempty"`
+	AffectedEntities    []affectedEntity    `json:"AFFECTED_ENTITIES"`
+	InterestingEntities interestingEntities `json:"INTERESTING_ENTITIES"`
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Build the "WithInfo" document returned by methods changing the repository.
+
+Input
+  - dataSourceCode: The DATA_SOURCE of the record changed.  Omitted if empty.
+  - recordID: The RECORD_ID of the record changed.  Omitted if empty.
+  - affectedEntityIDs: The ENTITY_IDs of the entities changed.
+  - flags: Flags of the call.  Without senzing.SzWithInfo, the document is empty.
+
+Output
+  - A JSON document or an empty string.
+*/
+func buildWithInfo(dataSourceCode string, recordID string, affectedEntityIDs []int64, flags int64) (string, error) {
+	if flags&senzing.SzWithInfo == 0 {
+		return "", nil
+	}
+	withInfo := withInfoResponse{
+		DataSource:       strings.ToUpper(dataSourceCode),
+		RecordID:         recordID,
+		AffectedEntities: []affectedEntity{},
+		InterestingEntities: interestingEntities{
+			Entities: []interface{}{},
+		},
+	}
+	for _, entityID := range affectedEntityIDs {
+		withInfo.AffectedEntities = append(withInfo.AffectedEntities, affectedEntity{EntityID: entityID})
+	}
+	response, err := json.Marshal(withInfo)
+	if err != nil {
+		return "", err
+	}
+	return string(response), nil
+}
+
+// Get the DATA_SOURCE and RECORD_ID of a redo record.  Both are empty if the redo record cannot be parsed.
+func parseRedoRecord(redoRecord string) (string, string, error) {
+	redo := struct {
+		DataSource string `json:"DATA_SOURCE"`
+		RecordID   string `json:"RECORD_ID"`
+	}{}
+	if err := json.Unmarshal([]byte(redoRecord), &redo); err != nil {
+		return "", "", err
+	}
+	return redo.DataSource, redo.RecordID, nil
+}
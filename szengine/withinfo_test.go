@@ -0,0 +1,95 @@
+package szengine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods - test
+// ----------------------------------------------------------------------------
+
+func TestWithInfo_AddRecord(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		AddRecordResult:  "static",
+		GenerateWithInfo: true,
+	}
+	actual, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	actual, err = szEngine.AddRecord(ctx, "CUSTOMERS", "1001", `{}`, senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestWithInfo_AddRecord_withRepository(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.GenerateWithInfo = true
+	actual, err := szEngine.AddRecord(ctx, "CUSTOMERS", "1004", `{"NAME_FULL": "Ann Jones"}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1004","AFFECTED_ENTITIES":[{"ENTITY_ID":3}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	actual, err = szEngine.AddRecord(ctx, "REFERENCE", "2002", `{"EMAIL_ADDRESS": "bsmith@example.com", "SSN_NUMBER": "294669999"}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"REFERENCE","RECORD_ID":"2002","AFFECTED_ENTITIES":[{"ENTITY_ID":1},{"ENTITY_ID":2}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+}
+
+func TestWithInfo_DeleteRecord_withRepository(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.GenerateWithInfo = true
+	actual, err := szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[{"ENTITY_ID":1},{"ENTITY_ID":3}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	actual, err = szEngine.DeleteRecord(ctx, "CUSTOMERS", "1001", senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+}
+
+func TestWithInfo_ProcessRedoRecord(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.GenerateWithInfo = true
+	actual, err := szEngine.ProcessRedoRecord(ctx, `{"REASON":"deferred","DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1003"}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1003","AFFECTED_ENTITIES":[{"ENTITY_ID":2}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	actual, err = szEngine.ProcessRedoRecord(ctx, `{"REASON":"deferred","DATA_SOURCE":"CUSTOMERS","RECORD_ID":"9999"}`, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"9999","AFFECTED_ENTITIES":[],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	_, err = szEngine.ProcessRedoRecord(ctx, `}{`, senzing.SzWithInfo)
+	require.Error(test, err)
+}
+
+func TestWithInfo_ReevaluateEntity(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := &Szengine{
+		GenerateWithInfo: true,
+	}
+	actual, err := szEngine.ReevaluateEntity(ctx, 1, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	szEngine = getResolverTestObject(ctx, test)
+	szEngine.GenerateWithInfo = true
+	actual, err = szEngine.ReevaluateEntity(ctx, 1, senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	_, err = szEngine.ReevaluateEntity(ctx, 99, senzing.SzWithInfo)
+	require.Error(test, err)
+}
+
+func TestWithInfo_ReevaluateRecord(test *testing.T) {
+	ctx := context.TODO()
+	szEngine := getResolverTestObject(ctx, test)
+	szEngine.GenerateWithInfo = true
+	actual, err := szEngine.ReevaluateRecord(ctx, "CUSTOMERS", "1002", senzing.SzWithInfo)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1002","AFFECTED_ENTITIES":[{"ENTITY_ID":1}],"INTERESTING_ENTITIES":{"ENTITIES":[]}}`, actual)
+	actual, err = szEngine.ReevaluateRecord(ctx, "CUSTOMERS", "1002", senzing.SzWithoutInfo)
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
@@ -6,3 +6,6 @@ package szproduct
 
 // Identfier of the szproduct package found messages having the format "senzing-6036xxxx".
 const ComponentID = 6036
+
+// Name of the szproduct package used in reports of helper.Chaos.
+const componentName = "szproduct"
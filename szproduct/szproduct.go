@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/senzing-garage/go-logging/logging"
@@ -14,16 +16,37 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
 	"github.com/senzing-garage/sz-sdk-go/szproduct"
 )
 
 type Szproduct struct {
-	isTrace        bool
-	LicenseResult  string
-	logger         logging.Logging
-	observerOrigin string
-	observers      subject.Subject
-	VersionResult  string
+	calls                    helper.CallRecorder
+	Chaos                    *helper.Chaos
+	DestroyError             error
+	DestroyFunc              func(ctx context.Context) error
+	Expectations             *helper.Expectations
+	GetLicenseError          error
+	GetLicenseFunc           func(ctx context.Context) (string, error)
+	GetVersionError          error
+	GetVersionFunc           func(ctx context.Context) (string, error)
+	HonorContext             bool
+	InitializeError          error
+	InitializeFunc           func(ctx context.Context, instanceName string, settings string, verboseLogging int64) error
+	isTrace                  atomic.Bool
+	Latencies                *helper.Latencies
+	LicenseResult            string
+	lifecycle                helper.Lifecycle
+	logger                   logging.Logging
+	mutex                    sync.RWMutex
+	notifications            helper.Notifications
+	observerOrigin           string
+	observers                subject.Subject
+	Responses                *helper.ResponseQueues
+	StrictLifecycle          bool
+	SynchronousNotifications bool
+	Triggers                 *helper.Triggers
+	VersionResult            string
 }
 
 const (
@@ -46,17 +69,31 @@ Input
 */
 func (client *Szproduct) Destroy(ctx context.Context) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(3)
 		defer func() { client.traceExit(4, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Destroy", err)
+	if err == nil {
+		err = client.DestroyError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Destroy", err)
+		err = helper.TriggeredError(client.Triggers, "Destroy", err)
+		err = helper.QueuedError(client.Responses, "Destroy", err)
+		err = helper.ExpectedError(client.Expectations, "Destroy", err)
+		if client.DestroyFunc != nil {
+			err = client.DestroyFunc(ctx)
+		}
+		err = client.lifecycle.Destroy(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8001, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8001, err, details)
+		})
 	}
+	client.record("Destroy", senzing.SzNoFlags, nil, err)
 	return err
 }
 
@@ -73,17 +110,31 @@ Output
 func (client *Szproduct) GetLicense(ctx context.Context) (string, error) {
 	var err error
 	result := client.LicenseResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(9)
 		defer func() { client.traceExit(10, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetLicense", err)
+	if err == nil {
+		err = client.GetLicenseError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetLicense", err)
+		err = helper.TriggeredError(client.Triggers, "GetLicense", err)
+		result, err = helper.QueuedResult(client.Responses, "GetLicense", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetLicense", result, err)
+		if client.GetLicenseFunc != nil {
+			result, err = client.GetLicenseFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8003, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8003, err, details)
+		})
 	}
+	client.record("GetLicense", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -100,17 +151,31 @@ Output
 func (client *Szproduct) GetVersion(ctx context.Context) (string, error) {
 	var err error
 	result := client.VersionResult
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(11)
 		defer func() { client.traceExit(12, result, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = client.lifecycle.Check(client.StrictLifecycle, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "GetVersion", err)
+	if err == nil {
+		err = client.GetVersionError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "GetVersion", err)
+		err = helper.TriggeredError(client.Triggers, "GetVersion", err)
+		result, err = helper.QueuedResult(client.Responses, "GetVersion", result, err)
+		result, err = helper.ExpectedResult(client.Expectations, "GetVersion", result, err)
+		if client.GetVersionFunc != nil {
+			result, err = client.GetVersionFunc(ctx)
+		}
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8004, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8004, err, details)
+		})
 	}
+	client.record("GetVersion", senzing.SzNoFlags, result, err)
 	return result, err
 }
 
@@ -118,6 +183,34 @@ func (client *Szproduct) GetVersion(ctx context.Context) (string, error) {
 // Public non-interface methods
 // ----------------------------------------------------------------------------
 
+/*
+The FlushNotifications method waits until observers have received the notifications
+sent asynchronously by the Szproduct object.
+Unnecessary if SynchronousNotifications is set.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - ctx.Err() if ctx was cancelled or expired before the notifications were delivered.
+*/
+func (client *Szproduct) FlushNotifications(ctx context.Context) error {
+	return client.notifications.Wait(ctx)
+}
+
+/*
+The GetCallRecorder method returns the log of calls made to the Szproduct object.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The CallRecorder holding the calls made to the Szproduct object.
+*/
+func (client *Szproduct) GetCallRecorder(ctx context.Context) *helper.CallRecorder {
+	return &client.calls
+}
+
 /*
 The GetObserverOrigin method returns the "origin" value of past Observer messages.
 
@@ -129,6 +222,8 @@ Output
 */
 func (client *Szproduct) GetObserverOrigin(ctx context.Context) string {
 	_ = ctx
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
 	return client.observerOrigin
 }
 
@@ -144,21 +239,35 @@ Input
 */
 func (client *Szproduct) Initialize(ctx context.Context, instanceName string, settings string, verboseLogging int64) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(13, instanceName, settings, verboseLogging)
 		defer func() { client.traceExit(14, instanceName, settings, verboseLogging, err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
-		go func() {
+	err = helper.ContextError(ctx, client.HonorContext, err)
+	err = helper.Delay(ctx, client.Latencies, componentName, "Initialize", err)
+	if err == nil {
+		err = client.InitializeError
+		err = helper.InjectFault(ctx, client.Chaos, componentName, "Initialize", err)
+		err = helper.TriggeredError(client.Triggers, "Initialize", err)
+		err = helper.QueuedError(client.Responses, "Initialize", err)
+		err = helper.ExpectedError(client.Expectations, "Initialize", err, instanceName, settings, verboseLogging)
+		if client.InitializeFunc != nil {
+			err = client.InitializeFunc(ctx, instanceName, settings, verboseLogging)
+		}
+		err = client.lifecycle.Initialize(client.StrictLifecycle, err)
+	}
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"instanceName":   instanceName,
 				"settings":       settings,
 				"verboseLogging": strconv.FormatInt(verboseLogging, baseTen),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8002, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8002, err, details)
+		})
 	}
+	client.record("Initialize", senzing.SzNoFlags, nil, err, instanceName, settings, verboseLogging)
 	return err
 }
 
@@ -171,22 +280,24 @@ Input
 */
 func (client *Szproduct) RegisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(703, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(704, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
+	client.mutex.Lock()
 	if client.observers == nil {
-		client.observers = &subject.SimpleSubject{}
+		client.observers = &helper.SynchronousSubject{}
 	}
 	err = client.observers.RegisterObserver(ctx, observer)
-	if client.observers != nil {
-		go func() {
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"observerID": observer.GetObserverID(ctx),
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8702, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8702, err, details)
+		})
 	}
 	return err
 }
@@ -200,7 +311,7 @@ Input
 */
 func (client *Szproduct) SetLogLevel(ctx context.Context, logLevelName string) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(705, logLevelName)
 		defer func() { client.traceExit(706, logLevelName, err, time.Since(entryTime)) }()
@@ -208,15 +319,17 @@ func (client *Szproduct) SetLogLevel(ctx context.Context, logLevelName string) e
 	if !logging.IsValidLogLevelName(logLevelName) {
 		return fmt.Errorf("invalid error level: %s", logLevelName)
 	}
+	client.mutex.Lock()
 	err = client.getLogger().SetLogLevel(logLevelName)
-	client.isTrace = (logLevelName == logging.LevelTraceName)
-	if client.observers != nil {
-		go func() {
+	client.isTrace.Store(logLevelName == logging.LevelTraceName)
+	client.mutex.Unlock()
+	if observers, observerOrigin := client.getObservers(); observers != nil {
+		client.notifications.Send(client.SynchronousNotifications, func() {
 			details := map[string]string{
 				"logLevelName": logLevelName,
 			}
-			notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8703, err, details)
-		}()
+			notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8703, err, details)
+		})
 	}
 	return err
 }
@@ -230,6 +343,8 @@ Input
 */
 func (client *Szproduct) SetObserverOrigin(ctx context.Context, origin string) {
 	_ = ctx
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.observerOrigin = origin
 }
 
@@ -242,23 +357,26 @@ Input
 */
 func (client *Szproduct) UnregisterObserver(ctx context.Context, observer observer.Observer) error {
 	var err error
-	if client.isTrace {
+	if client.isTrace.Load() {
 		entryTime := time.Now()
 		client.traceEntry(707, observer.GetObserverID(ctx))
 		defer func() { client.traceExit(708, observer.GetObserverID(ctx), err, time.Since(entryTime)) }()
 	}
-	if client.observers != nil {
+	if observers, observerOrigin := client.getObservers(); observers != nil {
 		// Tricky code:
-		// client.notify is called synchronously before client.observers is set to nil.
-		// In client.notify, each observer will get notified in a goroutine.
-		// Then client.observers may be set to nil, but observer goroutines will be OK.
+		// The observer being removed is notified, before it is removed, in the calling goroutine.
+		// client.mutex is not held, so observers may call the Szproduct object.
 		details := map[string]string{
 			"observerID": observer.GetObserverID(ctx),
 		}
-		notifier.Notify(ctx, client.observers, client.observerOrigin, ComponentID, 8704, err, details)
-		err = client.observers.UnregisterObserver(ctx, observer)
-		if !client.observers.HasObservers(ctx) {
-			client.observers = nil
+		notifier.Notify(ctx, observers, observerOrigin, ComponentID, 8704, err, details)
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		if client.observers != nil {
+			err = client.observers.UnregisterObserver(ctx, observer)
+			if !client.observers.HasObservers(ctx) {
+				client.observers = nil
+			}
 		}
 	}
 	return err
@@ -270,7 +388,7 @@ func (client *Szproduct) UnregisterObserver(ctx context.Context, observer observ
 
 // --- Logging ----------------------------------------------------------------
 
-// Get the Logger singleton.
+// Get the Logger singleton.  The caller holds client.mutex.
 func (client *Szproduct) getLogger() logging.Logging {
 	if client.logger == nil {
 		client.logger = helper.GetLogger(ComponentID, szproduct.IDMessages, baseCallerSkip)
@@ -278,12 +396,38 @@ func (client *Szproduct) getLogger() logging.Logging {
 	return client.logger
 }
 
+// Get the observers and the origin of their messages.
+func (client *Szproduct) getObservers() (subject.Subject, string) {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.observers, client.observerOrigin
+}
+
 // Trace method entry.
 func (client *Szproduct) traceEntry(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
 
 // Trace method exit.
 func (client *Szproduct) traceExit(errorNumber int, details ...interface{}) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
 	client.getLogger().Log(errorNumber, details...)
 }
+
+// ----------------------------------------------------------------------------
+// Call recording
+// ----------------------------------------------------------------------------
+
+// Record the call in the call log.
+func (client *Szproduct) record(method string, flags int64, result interface{}, err error, arguments ...interface{}) {
+	client.calls.Record(helper.Call{
+		Arguments: arguments,
+		Error:     err,
+		Flags:     flags,
+		Method:    method,
+		Result:    result,
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	truncator "github.com/aquilax/truncate"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/sz-sdk-go-mock/helper"
+	"github.com/senzing-garage/sz-sdk-go-mock/helper/helpertest"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -43,6 +46,139 @@ func TestSzproduct_GetLicense(test *testing.T) {
 	printActual(test, actual)
 }
 
+func TestSzproduct_GetLicense_error(test *testing.T) {
+	ctx := context.TODO()
+	szProduct := &Szproduct{
+		GetLicenseError: helper.NewSzErrorFromCatalog(helper.SzErrorLicense),
+	}
+	_, err := szProduct.GetLicense(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+}
+
+func TestSzproduct_GetLicense_func(test *testing.T) {
+	ctx := context.TODO()
+	calls := 0
+	szProduct := &Szproduct{
+		GetLicenseFunc: func(ctx context.Context) (string, error) {
+			_ = ctx
+			calls++
+			return fmt.Sprintf(`{"CALLS":%d}`, calls), nil
+		},
+		LicenseResult: "static",
+	}
+	for _, call := range []int{1, 2} {
+		actual, err := szProduct.GetLicense(ctx)
+		require.NoError(test, err)
+		assert.Equal(test, fmt.Sprintf(`{"CALLS":%d}`, call), actual)
+	}
+	assert.Equal(test, 2, szProduct.GetCallRecorder(ctx).Count("GetLicense"))
+}
+
+func TestSzproduct_GetLicense_latencies(test *testing.T) {
+	ctx := context.TODO()
+	latencies := helper.NewLatencies(1)
+	latencies.Set(componentName, "GetLicense", helper.FixedLatency(time.Hour))
+	szProduct := &Szproduct{
+		Latencies: latencies,
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := szProduct.GetLicense(ctxWithTimeout)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	latencies.Set(componentName, "GetLicense", helper.FixedLatency(time.Millisecond))
+	start := time.Now()
+	_, err = szProduct.GetLicense(ctx)
+	require.NoError(test, err)
+	assert.GreaterOrEqual(test, time.Since(start), time.Millisecond)
+}
+
+func TestSzproduct_GetLicense_chaos(test *testing.T) {
+	ctx := context.TODO()
+	chaos := helper.NewChaos(1, helper.ChaosSettings{})
+	chaos.Set(componentName, "GetLicense", helper.ChaosSettings{FailureRate: 1})
+	szProduct := &Szproduct{
+		Chaos: chaos,
+	}
+	_, err := szProduct.GetLicense(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzRetryable)
+	report := chaos.Report()
+	assert.Equal(test, map[string]int{helper.SzErrorDatabaseConnectionLost: 1}, report[componentName+".GetLicense"].Faults)
+	chaos.Set(componentName, "GetLicense", helper.ChaosSettings{})
+	_, err = szProduct.GetLicense(ctx)
+	require.NoError(test, err)
+}
+
+func TestSzproduct_GetLicense_triggers(test *testing.T) {
+	ctx := context.TODO()
+	triggers := helper.NewTriggers()
+	triggers.Add("GetLicense", helper.OnCall(2), helper.NewSzErrorFromCatalog(helper.SzErrorDatabaseConnectionLost))
+	szProduct := &Szproduct{
+		Triggers: triggers,
+	}
+	for call := 1; call <= 3; call++ {
+		_, err := szProduct.GetLicense(ctx)
+		if call == 2 {
+			require.ErrorIs(test, err, szerror.ErrSzRetryable)
+		} else {
+			require.NoError(test, err)
+		}
+	}
+	assert.Equal(test, 3, triggers.Calls("GetLicense"))
+}
+
+func TestSzproduct_GetLicense_honorContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	szProduct := &Szproduct{
+		HonorContext: true,
+	}
+	_, err := szProduct.GetLicense(ctx)
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestSzproduct_GetLicense_strictLifecycle(test *testing.T) {
+	ctx := context.TODO()
+	szProduct := &Szproduct{
+		StrictLifecycle: true,
+	}
+	_, err := szProduct.GetLicense(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+	require.NoError(test, szProduct.Initialize(ctx, "mock", "{}", senzing.SzNoLogging))
+	require.ErrorIs(test, szProduct.Initialize(ctx, "mock", "{}", senzing.SzNoLogging), szerror.ErrSzBadInput)
+	_, err = szProduct.GetLicense(ctx)
+	require.NoError(test, err)
+	require.NoError(test, szProduct.Destroy(ctx))
+	require.ErrorIs(test, szProduct.Destroy(ctx), szerror.ErrSzNotInitialized)
+	_, err = szProduct.GetLicense(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzNotInitialized)
+}
+
+func TestSzproduct_concurrent(test *testing.T) {
+	ctx := context.TODO()
+	expectations := helper.NewExpectations(test)
+	expectations.Expect("GetVersion").Times(8*50).Return(`{"VERSION":"4.0.0"}`, nil)
+	szProduct := &Szproduct{
+		Expectations: expectations,
+	}
+	helpertest.RunConcurrently(ctx, test, szProduct, 8, 50, func(worker int, iteration int) error {
+		_, err := szProduct.GetVersion(ctx)
+		return err
+	})
+	assert.Equal(test, 8*50, szProduct.GetCallRecorder(ctx).Count("GetVersion"))
+	expectations.Verify()
+}
+
+func TestSzproduct_GetLicense_expectations(test *testing.T) {
+	ctx := context.TODO()
+	expectations := helper.NewExpectations(test)
+	expectations.Expect("GetLicense").ReturnError(helper.NewSzErrorFromCatalog(helper.SzErrorLicense))
+	szProduct := &Szproduct{
+		Expectations: expectations,
+	}
+	_, err := szProduct.GetLicense(ctx)
+	require.ErrorIs(test, err, szerror.ErrSzLicense)
+}
+
 func TestSzproduct_GetVersion(test *testing.T) {
 	ctx := context.TODO()
 	szProduct := getTestObject(ctx, test)
@@ -55,6 +191,20 @@ func TestSzproduct_GetVersion(test *testing.T) {
 // Logging and observing
 // ----------------------------------------------------------------------------
 
+func TestSzproduct_GetVersion_responses(test *testing.T) {
+	ctx := context.TODO()
+	responses := &helper.ResponseQueues{}
+	responses.Enqueue("GetVersion", helper.Response{Error: helper.NewSzErrorFromCatalog(helper.SzErrorNotInitialized)}, helper.Response{Result: `{"VERSION":"4.0.0"}`})
+	szProduct := &Szproduct{
+		Responses: responses,
+	}
+	_, err := szProduct.GetVersion(ctx)
+	require.Error(test, err)
+	actual, err := szProduct.GetVersion(ctx)
+	require.NoError(test, err)
+	assert.Equal(test, `{"VERSION":"4.0.0"}`, actual)
+}
+
 func TestSzproduct_SetLogLevel_badLogLevelName(test *testing.T) {
 	ctx := context.TODO()
 	szConfig := getTestObject(ctx, test)
@@ -68,6 +218,17 @@ func TestSzproduct_SetObserverOrigin(test *testing.T) {
 	szProduct.SetObserverOrigin(ctx, origin)
 }
 
+func TestSzproduct_GetCallRecorder(test *testing.T) {
+	ctx := context.TODO()
+	expectedErr := helper.NewSzErrorFromCatalog(helper.SzErrorLicense)
+	szProduct := &Szproduct{GetLicenseError: expectedErr}
+	_, err := szProduct.GetLicense(ctx)
+	require.Error(test, err)
+	lastCall, ok := szProduct.GetCallRecorder(ctx).LastCall("GetLicense")
+	require.True(test, ok)
+	assert.Equal(test, expectedErr, lastCall.Error)
+}
+
 func TestSzproduct_GetObserverOrigin(test *testing.T) {
 	ctx := context.TODO()
 	szProduct := getTestObject(ctx, test)